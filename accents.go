@@ -0,0 +1,73 @@
+package bip39
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// The French and Spanish word lists are unambiguous without diacritics,
+// so users may type "elephant" for "éléphant". Matching ignores accents only
+// for these languages: in Japanese the dakuten are marks too.
+
+// IsMnemonicValidIgnoreAccents validates a mnemonic like IsMnemonicValid,
+// matching French and Spanish words with or without their accents
+func IsMnemonicValidIgnoreAccents(mnemonic string, lang Language) bool {
+	_, err := MnemonicToEntropyIgnoreAccents(mnemonic, lang)
+	return err == nil
+}
+
+// MnemonicToEntropyIgnoreAccents recovers the entropy like MnemonicToEntropy,
+// matching French and Spanish words with or without their accents
+func MnemonicToEntropyIgnoreAccents(mnemonic string, lang Language) ([]byte, error) {
+	return decodeMnemonic(mnemonic, lang, true)
+}
+
+// RestoreAccents returns the canonical form of a mnemonic typed without accents
+func RestoreAccents(mnemonic string, lang Language) (string, error) {
+	entropy, err := MnemonicToEntropyIgnoreAccents(mnemonic, lang)
+	if err != nil {
+		return "", err
+	}
+	return NewMnemonicByEntropy(entropy, lang)
+}
+
+func hasAccents(lang Language) bool {
+	return lang == French || lang == Spanish
+}
+
+// accentlessMapping records the index of every word with its accents stripped
+func accentlessMapping(lang Language) map[string]int {
+	wordMapping := make(map[string]int)
+	for idx, v := range lang.List() {
+		wordMapping[stripMarks(norm.NFKD.String(v))] = idx
+	}
+	return wordMapping
+}
+
+// restoreAccents replaces bare words by their accented form, leaving
+// unknown words alone
+func restoreAccents(words []string, lang Language) ([]string, bool) {
+	if !hasAccents(lang) {
+		return words, false
+	}
+	list := lang.List()
+	mapping := accentlessMapping(lang)
+	return filterWords(words, func(word string) string {
+		if idx, has := mapping[stripMarks(word)]; has {
+			return list[idx]
+		}
+		return word
+	})
+}
+
+// stripMarks removes the accents of a NFKD normalized word
+func stripMarks(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
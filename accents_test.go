@@ -0,0 +1,87 @@
+package bip39_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/adesight/bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+func stripAccents(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(s))
+}
+
+func TestIgnoreAccents(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		lang     bip39.Language
+	}{
+		{
+			name:     "French",
+			mnemonic: "élève éléphant élégant abaisser abaisser abaisser abaisser abaisser abaisser abaisser abaisser abaisser",
+			lang:     bip39.French,
+		},
+		{
+			name:     "Spanish",
+			mnemonic: "afirmar exceso sufrir clan treinta lucha pitón escala nuca aparato lobo garaje",
+			lang:     bip39.Spanish,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// fix the checksum of the hand written phrase
+			words := strings.Fields(tt.mnemonic)
+			for _, last := range tt.lang.List() {
+				words[len(words)-1] = last
+				if bip39.IsMnemonicValid(strings.Join(words, " "), tt.lang) {
+					break
+				}
+			}
+			canonical := strings.Join(words, " ")
+			bare := stripAccents(canonical)
+			if bare == norm.NFKD.String(canonical) {
+				t.Fatalf("%q has no accents", canonical)
+			}
+
+			if bip39.IsMnemonicValid(bare, tt.lang) {
+				t.Errorf("IsMnemonicValid(%q) = true, want false", bare)
+			}
+			if !bip39.IsMnemonicValidIgnoreAccents(bare, tt.lang) {
+				t.Errorf("IsMnemonicValidIgnoreAccents(%q) = false, want true", bare)
+			}
+
+			want, _ := bip39.MnemonicToEntropy(canonical, tt.lang)
+			got, err := bip39.MnemonicToEntropyIgnoreAccents(bare, tt.lang)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Errorf("MnemonicToEntropyIgnoreAccents() = %x, %v, want %x", got, err, want)
+			}
+
+			restored, err := bip39.RestoreAccents(bare, tt.lang)
+			if err != nil || norm.NFKD.String(restored) != norm.NFKD.String(canonical) {
+				t.Errorf("RestoreAccents() = %v, %v, want %v", restored, err, canonical)
+			}
+
+			normalized, applied, err := bip39.ParseMnemonic(strings.ToUpper(bare), tt.lang)
+			if err != nil || normalized != norm.NFKD.String(canonical) {
+				t.Errorf("ParseMnemonic() = %v, %v, want %v", normalized, err, canonical)
+			}
+			if len(applied) != 2 || applied[1] != bip39.RestoredAccents {
+				t.Errorf("ParseMnemonic() applied = %v", applied)
+			}
+		})
+	}
+
+	// dakuten are marks, Japanese must match exactly
+	if bip39.IsMnemonicValidIgnoreAccents("あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あおそら", bip39.Japanese) {
+		t.Errorf("IsMnemonicValidIgnoreAccents() = true for Japanese without dakuten")
+	}
+}
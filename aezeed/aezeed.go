@@ -0,0 +1,262 @@
+// Package aezeed implements LND's aezeed cipher seed scheme.
+//
+// An aezeed mnemonic is 24 words over the BIP39 English word list. It
+// carries a version, the wallet birthday and 16 bytes of entropy, all
+// enciphered with AEZ under a scrypt-derived key and protected by a
+// CRC32 checksum.
+package aezeed
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"strings"
+	"time"
+
+	"github.com/Yawning/aez"
+	"github.com/adesight/bip39/internal/wordlist"
+	"golang.org/x/crypto/scrypt"
+)
+
+// CipherSeedVersion is the current version of the enciphered seed format
+const CipherSeedVersion uint8 = 0
+
+// Sizes of the aezeed format
+const (
+	EntropySize      = 16
+	NumMnemonicWords = 24
+
+	saltSize            = 5
+	checksumSize        = 4
+	cipherTextExpansion = 4
+	decipheredSize      = 1 + 2 + EntropySize
+	encipheredSize      = 1 + decipheredSize + cipherTextExpansion + saltSize + checksumSize
+	saltOffset          = encipheredSize - checksumSize - saltSize
+	checksumOffset      = encipheredSize - checksumSize
+)
+
+const (
+	defaultPassphrase = "aezeed"
+	keyLen            = 32
+)
+
+// scrypt cost of the password key, variables so that tests can lower it
+var (
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// BitcoinGenesisDate is the day zero of the seed birthday
+var BitcoinGenesisDate = time.Unix(1231006505, 0)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Error list
+var (
+	ErrWordLen         = errors.New("Invalid aezeed word list length")
+	ErrUnknownWord     = errors.New("Unknown aezeed word")
+	ErrEntropyLen      = errors.New("Invalid aezeed entropy length")
+	ErrUnknownVersion  = errors.New("Unknown aezeed version")
+	ErrChecksum        = errors.New("Invalid aezeed checksum")
+	ErrInvalidPassword = errors.New("Invalid aezeed password")
+)
+
+// CipherSeed is the decoded content of an aezeed mnemonic
+type CipherSeed struct {
+	// InternalVersion is the version of the seed derivation scheme
+	InternalVersion uint8
+	// Birthday is the number of days since the bitcoin genesis block
+	Birthday uint16
+	// Entropy is the root entropy the wallet is derived from
+	Entropy [EntropySize]byte
+
+	salt [saltSize]byte
+}
+
+// New creates a cipher seed from entropy, which is generated when nil.
+// param now sets the birthday of the seed
+func New(internalVersion uint8, entropy []byte, now time.Time) (*CipherSeed, error) {
+	seed := &CipherSeed{
+		InternalVersion: internalVersion,
+		Birthday:        uint16(now.Sub(BitcoinGenesisDate) / (24 * time.Hour)),
+	}
+
+	switch len(entropy) {
+	case 0:
+		if _, err := rand.Read(seed.Entropy[:]); err != nil {
+			return nil, err
+		}
+	case EntropySize:
+		copy(seed.Entropy[:], entropy)
+	default:
+		return nil, ErrEntropyLen
+	}
+
+	if _, err := rand.Read(seed.salt[:]); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// BirthdayTime returns the birthday as a point in time
+func (c *CipherSeed) BirthdayTime() time.Time {
+	return BitcoinGenesisDate.Add(time.Duration(c.Birthday) * 24 * time.Hour)
+}
+
+// Encipher encrypts the seed with passwd.
+// param passwd can be empty string
+func (c *CipherSeed) Encipher(passwd string) ([]byte, error) {
+	key, err := stretchKey(passwd, c.salt[:])
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, decipheredSize)
+	plaintext[0] = c.InternalVersion
+	binary.BigEndian.PutUint16(plaintext[1:3], c.Birthday)
+	copy(plaintext[3:], c.Entropy[:])
+
+	res := make([]byte, encipheredSize)
+	res[0] = CipherSeedVersion
+	copy(res[1:saltOffset], aez.Encrypt(key, nil, [][]byte{c.ad()}, cipherTextExpansion, plaintext, nil))
+	copy(res[saltOffset:], c.salt[:])
+	binary.BigEndian.PutUint32(res[checksumOffset:], crc32.Checksum(res[:checksumOffset], crcTable))
+	return res, nil
+}
+
+// ToMnemonic encrypts the seed with passwd and encodes it as 24 words.
+// param passwd can be empty string
+func (c *CipherSeed) ToMnemonic(passwd string) (string, error) {
+	enciphered, err := c.Encipher(passwd)
+	if err != nil {
+		return "", err
+	}
+	return encodeMnemonic(enciphered), nil
+}
+
+func (c *CipherSeed) ad() []byte {
+	return append([]byte{CipherSeedVersion}, c.salt[:]...)
+}
+
+// Decipher decrypts an enciphered seed produced by Encipher
+func Decipher(enciphered []byte, passwd string) (*CipherSeed, error) {
+	if err := verifyEnciphered(enciphered); err != nil {
+		return nil, err
+	}
+
+	seed := new(CipherSeed)
+	copy(seed.salt[:], enciphered[saltOffset:checksumOffset])
+
+	key, err := stretchKey(passwd, seed.salt[:])
+	if err != nil {
+		return nil, err
+	}
+	plaintext, ok := aez.Decrypt(key, nil, [][]byte{seed.ad()}, cipherTextExpansion, enciphered[1:saltOffset], nil)
+	if !ok {
+		return nil, ErrInvalidPassword
+	}
+
+	seed.InternalVersion = plaintext[0]
+	seed.Birthday = binary.BigEndian.Uint16(plaintext[1:3])
+	copy(seed.Entropy[:], plaintext[3:])
+	return seed, nil
+}
+
+// FromMnemonic decodes and decrypts an aezeed mnemonic.
+// param passwd can be empty string
+func FromMnemonic(mnemonic string, passwd string) (*CipherSeed, error) {
+	enciphered, err := decodeMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return Decipher(enciphered, passwd)
+}
+
+// ChangePassword re-encrypts a mnemonic under a new password.
+// The salt is refreshed so the new mnemonic is unrelated to the old one.
+func ChangePassword(mnemonic string, oldPasswd, newPasswd string) (string, error) {
+	seed, err := FromMnemonic(mnemonic, oldPasswd)
+	if err != nil {
+		return "", err
+	}
+	if _, err := rand.Read(seed.salt[:]); err != nil {
+		return "", err
+	}
+	return seed.ToMnemonic(newPasswd)
+}
+
+// IsMnemonicValid checks the words, version and checksum of a mnemonic.
+// The password is not required, so the content is not decrypted.
+func IsMnemonicValid(mnemonic string) bool {
+	enciphered, err := decodeMnemonic(mnemonic)
+	if err != nil {
+		return false
+	}
+	return verifyEnciphered(enciphered) == nil
+}
+
+func verifyEnciphered(enciphered []byte) error {
+	if len(enciphered) != encipheredSize {
+		return ErrWordLen
+	}
+	if enciphered[0] != CipherSeedVersion {
+		return ErrUnknownVersion
+	}
+	checksum := binary.BigEndian.Uint32(enciphered[checksumOffset:])
+	if crc32.Checksum(enciphered[:checksumOffset], crcTable) != checksum {
+		return ErrChecksum
+	}
+	return nil
+}
+
+func stretchKey(passwd string, salt []byte) ([]byte, error) {
+	if passwd == "" {
+		passwd = defaultPassphrase
+	}
+	return scrypt.Key([]byte(passwd), salt, scryptN, scryptR, scryptP, keyLen)
+}
+
+func encodeMnemonic(enciphered []byte) string {
+	words := make([]string, 0, NumMnemonicWords)
+	var acc, bits uint32
+	for _, v := range enciphered {
+		acc = acc<<8 | uint32(v)
+		bits += 8
+		for bits >= 11 {
+			bits -= 11
+			words = append(words, wordlist.English[acc>>bits&0x7ff])
+		}
+	}
+	return strings.Join(words, "\x20")
+}
+
+func decodeMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) != NumMnemonicWords {
+		return nil, ErrWordLen
+	}
+
+	// record index of word
+	wordMapping := make(map[string]uint32, len(wordlist.English))
+	for idx, v := range wordlist.English {
+		wordMapping[v] = uint32(idx)
+	}
+
+	res := make([]byte, 0, encipheredSize)
+	var acc, bits uint32
+	for _, v := range words {
+		idx, has := wordMapping[v]
+		if !has {
+			return nil, ErrUnknownWord
+		}
+		acc = acc<<11 | idx
+		bits += 11
+		for bits >= 8 {
+			bits -= 8
+			res = append(res, byte(acc>>bits))
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,124 @@
+package aezeed
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testEntropy, _ = hex.DecodeString("81b637d86359e6960de795e41e0b4cfd")
+	testSalt       = [saltSize]byte{0x73, 0x61, 0x6c, 0x74, 0x31}
+)
+
+func TestCipherSeed_ToMnemonic(t *testing.T) {
+	// LND computes its vectors with a lowered scrypt cost
+	defer func(n int) { scryptN = n }(scryptN)
+	scryptN = 16
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		passwd   string
+		want     string
+		birthday uint16
+	}{
+		// LND's version 0 vectors
+		{
+			name:     "genesis",
+			now:      BitcoinGenesisDate,
+			passwd:   "",
+			want:     "ability liquid travel stem barely drastic pact cupboard apple thrive morning oak feature tissue couch old math inform success suggest drink motion know royal",
+			birthday: 0,
+		},
+		{
+			name:     "password",
+			now:      time.Unix(1521799345, 0),
+			passwd:   "!very_safe_55345_password*",
+			want:     "able tree stool crush transfer cloud cross three profit outside hen citizen plate ride require leg siren drum success suggest drink require fiscal upgrade",
+			birthday: 3365,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, err := New(0, testEntropy, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seed.salt = testSalt
+			if seed.Birthday != tt.birthday {
+				t.Errorf("CipherSeed.Birthday = %v, want %v", seed.Birthday, tt.birthday)
+			}
+			got, err := seed.ToMnemonic(tt.passwd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("CipherSeed.ToMnemonic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMnemonic(t *testing.T) {
+	now := time.Unix(1521799345, 0)
+	seed, err := New(0, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mnemonic, err := seed.ToMnemonic("!very_safe_55345_password*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsMnemonicValid(mnemonic) {
+		t.Errorf("IsMnemonicValid() = false, want true")
+	}
+
+	got, err := FromMnemonic(mnemonic, "!very_safe_55345_password*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.InternalVersion != 0 || got.Birthday != 3365 || !bytes.Equal(got.Entropy[:], seed.Entropy[:]) {
+		t.Errorf("FromMnemonic() = %+v, want %+v", got, seed)
+	}
+	if got.BirthdayTime().After(now) {
+		t.Errorf("CipherSeed.BirthdayTime() = %v, want before %v", got.BirthdayTime(), now)
+	}
+
+	if _, err := FromMnemonic(mnemonic, "wrong"); err != ErrInvalidPassword {
+		t.Errorf("FromMnemonic() error = %v, want %v", err, ErrInvalidPassword)
+	}
+
+	changed, err := ChangePassword(mnemonic, "!very_safe_55345_password*", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := FromMnemonic(changed, ""); err != nil || got.Entropy != seed.Entropy {
+		t.Errorf("ChangePassword() lost entropy, error = %v", err)
+	}
+}
+
+func TestIsMnemonicValid(t *testing.T) {
+	valid := "ability liquid travel stem barely drastic pact cupboard apple thrive morning oak feature tissue couch old math inform success suggest drink motion know royal"
+	tests := []struct {
+		name     string
+		mnemonic string
+		want     bool
+	}{
+		{name: "valid", mnemonic: valid, want: true},
+		{name: "checksum", mnemonic: strings.Replace(valid, "royal", "loyal", 1), want: false},
+		{name: "length", mnemonic: strings.TrimSuffix(valid, " royal"), want: false},
+		{name: "unknown word", mnemonic: strings.Replace(valid, "royal", "lnd", 1), want: false},
+		{name: "bip39", mnemonic: "check fiscal fit sword unlock rough lottery tool sting pluck bulb random", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMnemonicValid(tt.mnemonic); got != tt.want {
+				t.Errorf("IsMnemonicValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
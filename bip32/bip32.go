@@ -0,0 +1,359 @@
+// Package bip32 implements BIP32 hierarchical deterministic keys on secp256k1.
+//
+// It is the derivation layer underneath BIP85 and the coin presets: a
+// master key is created from the 64 bytes seed returned by
+// bip39.MnemonicToSeed and child keys are derived by path.
+package bip32
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/internal/anylang"
+	"github.com/adesight/bip39/internal/base58"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // HASH160 is part of BIP32
+)
+
+// HardenedOffset is the first hardened child index
+const HardenedOffset uint32 = 0x80000000
+
+const (
+	serializedLen = 78
+	minSeedLen    = 16
+	maxSeedLen    = 64
+)
+
+// Version bytes of the serialization format
+var (
+	MainnetPrivate = [4]byte{0x04, 0x88, 0xad, 0xe4} // xprv
+	MainnetPublic  = [4]byte{0x04, 0x88, 0xb2, 0x1e} // xpub
+	TestnetPrivate = [4]byte{0x04, 0x35, 0x83, 0x94} // tprv
+	TestnetPublic  = [4]byte{0x04, 0x35, 0x87, 0xcf} // tpub
+)
+
+var masterSecret = []byte("Bitcoin seed")
+
+// Error list
+var (
+	ErrInvalidSeed    = errors.New("Invalid seed length")
+	ErrInvalidKey     = errors.New("Invalid extended key")
+	ErrInvalidPath    = errors.New("Invalid derivation path")
+	ErrInvalidChild   = errors.New("Invalid child key, use the next index")
+	ErrHardenedPublic = errors.New("Cannot derive a hardened child from a public key")
+	ErrDepthExceeded  = errors.New("Maximum derivation depth exceeded")
+)
+
+// Key is an extended private or public key
+type Key struct {
+	version     [4]byte
+	depth       uint8
+	parentFP    [4]byte
+	childNumber uint32
+	chainCode   [32]byte
+	// key is 0x00 || private key for private keys, a compressed point otherwise
+	key [33]byte
+}
+
+// NewMasterKey creates the master key from a seed
+func NewMasterKey(seed []byte) (*Key, error) {
+	if len(seed) < minSeedLen || len(seed) > maxSeedLen {
+		return nil, ErrInvalidSeed
+	}
+	mac := hmac.New(sha512.New, masterSecret)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(sum[:32]); overflow || k.IsZero() {
+		return nil, ErrInvalidSeed
+	}
+	master := &Key{version: MainnetPrivate}
+	copy(master.key[1:], sum[:32])
+	copy(master.chainCode[:], sum[32:])
+	return master, nil
+}
+
+// NewMasterKeyFromMnemonic creates the master key from the seed of a mnemonic,
+// which must be valid in one of the BIP39 word lists.
+// param passwd can be empty string
+func NewMasterKeyFromMnemonic(mnemonic string, passwd string) (*Key, error) {
+	if !anylang.IsMnemonicValid(mnemonic) {
+		return nil, bip39.ErrInvalidMnemonic
+	}
+	seed, err := bip39.MnemonicToSeed(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+	return NewMasterKey(seed)
+}
+
+// NewKey assembles a master private key from raw key material
+func NewKey(privateKey, chainCode []byte) (*Key, error) {
+	if len(privateKey) != 32 || len(chainCode) != 32 {
+		return nil, ErrInvalidKey
+	}
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(privateKey); overflow || k.IsZero() {
+		return nil, ErrInvalidKey
+	}
+	key := &Key{version: MainnetPrivate}
+	copy(key.key[1:], privateKey)
+	copy(key.chainCode[:], chainCode)
+	return key, nil
+}
+
+// ParseKey decodes a base58check serialized extended key
+func ParseKey(s string) (*Key, error) {
+	data, err := base58.CheckDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != serializedLen {
+		return nil, ErrInvalidKey
+	}
+
+	key := new(Key)
+	copy(key.version[:], data[0:4])
+	key.depth = data[4]
+	copy(key.parentFP[:], data[5:9])
+	key.childNumber = binary.BigEndian.Uint32(data[9:13])
+	copy(key.chainCode[:], data[13:45])
+	copy(key.key[:], data[45:78])
+
+	if key.depth == 0 && (key.childNumber != 0 || key.parentFP != [4]byte{}) {
+		return nil, ErrInvalidKey
+	}
+	switch key.version {
+	case MainnetPrivate, TestnetPrivate:
+		var k secp256k1.ModNScalar
+		if overflow := k.SetByteSlice(key.key[1:]); key.key[0] != 0 || overflow || k.IsZero() {
+			return nil, ErrInvalidKey
+		}
+	case MainnetPublic, TestnetPublic:
+		if _, err := secp256k1.ParsePubKey(key.key[:]); err != nil {
+			return nil, ErrInvalidKey
+		}
+	default:
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// ParsePath parses a path like "m/44'/0'/0'/0/1" into child indices.
+// Hardened indices are marked with ', h or H
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" && parts[0] != "M" {
+		return nil, ErrInvalidPath
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, v := range parts[1:] {
+		var offset uint32
+		if n := len(v); n > 0 && (v[n-1] == '\'' || v[n-1] == 'h' || v[n-1] == 'H') {
+			offset, v = HardenedOffset, v[:n-1]
+		}
+		idx, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || uint32(idx) >= HardenedOffset {
+			return nil, ErrInvalidPath
+		}
+		indices = append(indices, uint32(idx)+offset)
+	}
+	return indices, nil
+}
+
+// Derive derives the descendant key at path, relative to this key
+func (k *Key) Derive(path string) (*Key, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return k.DeriveIndices(indices...)
+}
+
+// DeriveIndices derives the descendant key through indices
+func (k *Key) DeriveIndices(indices ...uint32) (*Key, error) {
+	key := k
+	for _, idx := range indices {
+		child, err := key.Child(idx)
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	return key, nil
+}
+
+// Child derives the child key at index.
+// Indices from HardenedOffset on are hardened
+func (k *Key) Child(index uint32) (*Key, error) {
+	if k.depth == 0xff {
+		return nil, ErrDepthExceeded
+	}
+	hardened := index >= HardenedOffset
+	if hardened && !k.IsPrivate() {
+		return nil, ErrHardenedPublic
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, k.key[:]...)
+	} else {
+		data = append(data, k.PublicKey()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var tweak secp256k1.ModNScalar
+	if overflow := tweak.SetByteSlice(sum[:32]); overflow {
+		return nil, ErrInvalidChild
+	}
+
+	child := &Key{
+		version:     k.version,
+		depth:       k.depth + 1,
+		childNumber: index,
+	}
+	copy(child.parentFP[:], k.Fingerprint())
+	copy(child.chainCode[:], sum[32:])
+
+	if k.IsPrivate() {
+		var parent secp256k1.ModNScalar
+		parent.SetByteSlice(k.key[1:])
+		tweak.Add(&parent)
+		if tweak.IsZero() {
+			return nil, ErrInvalidChild
+		}
+		b := tweak.Bytes()
+		copy(child.key[1:], b[:])
+		return child, nil
+	}
+
+	pub, err := secp256k1.ParsePubKey(k.key[:])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	var point, tweakPoint secp256k1.JacobianPoint
+	pub.AsJacobian(&point)
+	secp256k1.ScalarBaseMultNonConst(&tweak, &tweakPoint)
+	secp256k1.AddNonConst(&point, &tweakPoint, &point)
+	if (point.X.IsZero() && point.Y.IsZero()) || point.Z.IsZero() {
+		return nil, ErrInvalidChild
+	}
+	point.ToAffine()
+	copy(child.key[:], secp256k1.NewPublicKey(&point.X, &point.Y).SerializeCompressed())
+	return child, nil
+}
+
+// Neuter returns the public version of the key
+func (k *Key) Neuter() *Key {
+	if !k.IsPrivate() {
+		return k
+	}
+	pub := *k
+	switch k.version {
+	case TestnetPrivate:
+		pub.version = TestnetPublic
+	default:
+		pub.version = MainnetPublic
+	}
+	copy(pub.key[:], k.PublicKey())
+	return &pub
+}
+
+// IsPrivate reports whether k is a private key. It looks at the key data,
+// not at the version bytes which SetVersion may change
+func (k *Key) IsPrivate() bool {
+	return k.key[0] == 0
+}
+
+// PrivateKey returns the 32 bytes private key, or nil for public keys
+func (k *Key) PrivateKey() []byte {
+	if !k.IsPrivate() {
+		return nil
+	}
+	return append([]byte(nil), k.key[1:]...)
+}
+
+// PublicKey returns the 33 bytes compressed public key
+func (k *Key) PublicKey() []byte {
+	if !k.IsPrivate() {
+		return append([]byte(nil), k.key[:]...)
+	}
+	return secp256k1.PrivKeyFromBytes(k.key[1:]).PubKey().SerializeCompressed()
+}
+
+// ChainCode returns the chain code
+func (k *Key) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode[:]...)
+}
+
+// Depth returns the number of derivations from the master key
+func (k *Key) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index this key was derived at
+func (k *Key) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// ParentFingerprint returns the fingerprint of the parent key
+func (k *Key) ParentFingerprint() []byte {
+	return append([]byte(nil), k.parentFP[:]...)
+}
+
+// Fingerprint returns the first 4 bytes of HASH160 of the public key
+func (k *Key) Fingerprint() []byte {
+	return Hash160(k.PublicKey())[:4]
+}
+
+// SetVersion returns a copy of the key serialized with different version bytes,
+// e.g. for testnet or SLIP-132 prefixes
+func (k *Key) SetVersion(version [4]byte) *Key {
+	key := *k
+	key.version = version
+	return &key
+}
+
+// Version returns the version bytes of the key
+func (k *Key) Version() [4]byte {
+	return k.version
+}
+
+// Serialize returns the 78 bytes serialization of the key
+func (k *Key) Serialize() []byte {
+	var buf bytes.Buffer
+	buf.Grow(serializedLen)
+	buf.Write(k.version[:])
+	buf.WriteByte(k.depth)
+	buf.Write(k.parentFP[:])
+	buf.Write(binary.BigEndian.AppendUint32(nil, k.childNumber))
+	buf.Write(k.chainCode[:])
+	buf.Write(k.key[:])
+	return buf.Bytes()
+}
+
+// String returns the base58check serialization, e.g. xprv... or xpub...
+func (k *Key) String() string {
+	return base58.CheckEncode(k.Serialize())
+}
+
+// Hash160 returns RIPEMD160(SHA256(data))
+func Hash160(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)
+}
@@ -0,0 +1,172 @@
+package bip32
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		name string
+		seed string
+		path string
+		xprv string
+		xpub string
+	}{
+		{
+			name: "vector 1 master",
+			seed: "000102030405060708090a0b0c0d0e0f",
+			path: "m",
+			xprv: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			xpub: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			name: "vector 1 m/0H",
+			seed: "000102030405060708090a0b0c0d0e0f",
+			path: "m/0H",
+			xprv: "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			xpub: "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		},
+		{
+			name: "vector 1 m/0H/1/2H/2/1000000000",
+			seed: "000102030405060708090a0b0c0d0e0f",
+			path: "m/0'/1/2'/2/1000000000",
+			xprv: "xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76",
+			xpub: "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+		},
+		{
+			name: "vector 3 leading zeros",
+			seed: "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
+			path: "m/0H",
+			xprv: "xprv9uPDJpEQgRQfDcW7BkF7eTya6RPxXeJCqCJGHuCJ4GiRVLzkTXBAJMu2qaMWPrS7AANYqdq6vcBcBUdJCVVFceUvJFjaPdGZ2y9WACViL4L",
+			xpub: "xpub68NZiKmJWnxxS6aaHmn81bvJeTESw724CRDs6HbuccFQN9Ku14VQrADWgqbhhTHBaohPX4CjNLf9fq9MYo6oDaPPLPxSb7gwQN3ih19Zm4Y",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, _ := hex.DecodeString(tt.seed)
+			master, err := NewMasterKey(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := master.Derive(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := key.String(); got != tt.xprv {
+				t.Errorf("Derive() = %v, want %v", got, tt.xprv)
+			}
+			if got := key.Neuter().String(); got != tt.xpub {
+				t.Errorf("Neuter() = %v, want %v", got, tt.xpub)
+			}
+
+			parsed, err := ParseKey(tt.xprv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.String() != tt.xprv {
+				t.Errorf("ParseKey() = %v, want %v", parsed, tt.xprv)
+			}
+		})
+	}
+}
+
+func TestNewMasterKeyFromMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		wantErr  error
+	}{
+		{"english", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", nil},
+		{"japanese", "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら", nil},
+		{"empty", "", bip39.ErrInvalidMnemonic},
+		{"checksum", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", bip39.ErrInvalidMnemonic},
+		{"unknown word", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon bitcoin", bip39.ErrInvalidMnemonic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMasterKeyFromMnemonic(tt.mnemonic, ""); err != tt.wantErr {
+				t.Errorf("NewMasterKeyFromMnemonic() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPublicDerivation(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewMasterKey(seed)
+	parent, _ := master.Derive("m/0'/1/2'")
+
+	fromPrivate, err := parent.Derive("m/2/1000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromPublic, err := parent.Neuter().Derive("m/2/1000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromPrivate.Neuter().String() != fromPublic.String() {
+		t.Errorf("public derivation = %v, want %v", fromPublic, fromPrivate.Neuter())
+	}
+	if _, err := parent.Neuter().Child(HardenedOffset); err != ErrHardenedPublic {
+		t.Errorf("Child() error = %v, want %v", err, ErrHardenedPublic)
+	}
+}
+
+func TestSetVersion(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SLIP-132 zprv
+	zprv := master.SetVersion([4]byte{0x04, 0xb2, 0x43, 0x0c})
+	if !zprv.IsPrivate() {
+		t.Fatalf("IsPrivate() = false after SetVersion")
+	}
+	if !bytes.Equal(zprv.PublicKey(), master.PublicKey()) || !bytes.Equal(zprv.Fingerprint(), master.Fingerprint()) {
+		t.Errorf("PublicKey() = %x, want %x", zprv.PublicKey(), master.PublicKey())
+	}
+	if got, want := zprv.Neuter().String(), master.Neuter().String(); got != want {
+		t.Errorf("Neuter() = %v, want %v", got, want)
+	}
+
+	xpub := master.Neuter().SetVersion(MainnetPrivate)
+	if xpub.IsPrivate() || xpub.PrivateKey() != nil {
+		t.Errorf("IsPrivate() = true for a public key with private version bytes")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []uint32
+		wantErr bool
+	}{
+		{path: "m", want: []uint32{}},
+		{path: "m/44'/0h/0H/1/2", want: []uint32{44 + HardenedOffset, HardenedOffset, HardenedOffset, 1, 2}},
+		{path: "44'/0'", wantErr: true},
+		{path: "m/", wantErr: true},
+		{path: "m/2147483648", wantErr: true},
+		{path: "m/-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && len(got) != len(tt.want) {
+			t.Errorf("ParsePath(%q) = %v, want %v", tt.path, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParsePath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		}
+	}
+}
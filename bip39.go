@@ -4,13 +4,11 @@ package bip39
 import (
 	"crypto/rand"
 	"crypto/sha256"
-	"crypto/sha512"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
-	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/text/unicode/norm"
 )
 
@@ -85,63 +83,77 @@ func entropyToMnemonic(entropy []byte, wordsLen int, lang Language) (string, err
 		words = append(words, wordList[idx])
 	}
 
-	if lang == Japanese {
-		return strings.Join(words, "\u3000"), nil
-	}
-	return strings.Join(words, "\x20"), nil
+	return strings.Join(words, lang.Separator()), nil
 }
 
 // MnemonicToSeed creates seed by mnemonic.
 // param passwd can be empty string
 func MnemonicToSeed(mnemonic string, passwd string) ([]byte, error) {
-	if mnemonic == "" {
-		return nil, ErrInvalidMnemonic
-	}
-	reschan := make(chan []byte)
-	go func() {
-		defer close(reschan)
-		password := []byte(norm.NFKD.String(mnemonic))
-		salt := []byte(norm.NFKD.String("mnemonic" + passwd))
-		reschan <- pbkdf2.Key(password, salt, 2048, 64, sha512.New)
-	}()
-	return <-reschan, nil
+	return standardSeedDeriver.Derive(mnemonic, passwd, English)
 }
 
 // IsMnemonicValid validate menemonic
 func IsMnemonicValid(mnemonic string, lang Language) bool {
+	_, err := MnemonicToEntropy(mnemonic, lang)
+	return err == nil
+}
+
+// MnemonicToEntropy recovers the entropy encoded by mnemonic
+func MnemonicToEntropy(mnemonic string, lang Language) ([]byte, error) {
+	return decodeMnemonic(mnemonic, lang, false)
+}
+
+func decodeMnemonic(mnemonic string, lang Language, ignoreAccents bool) ([]byte, error) {
 	mnemonic = norm.NFKD.String(mnemonic)
 	wordList := strings.Split(mnemonic, "\x20")
 
 	wordCount := len(wordList)
 	if wordCount%3 != 0 || wordCount < 12 || wordCount > 24 {
-		return false
+		return nil, ErrInvalidMnemonic
 	}
 
 	// record index of word
-	wordMapping := make(map[string]int)
-	for idx, v := range lang.List() {
-		wordMapping[v] = idx
+	var wordMapping map[string]int
+	if ignoreAccents && hasAccents(lang) {
+		wordMapping = accentlessMapping(lang)
+		for i, v := range wordList {
+			wordList[i] = stripMarks(v)
+		}
+	} else {
+		wordMapping = indexMapping(lang)
 	}
 
 	binEnt := mnemonicToEntropy(wordList, wordMapping)
 	if binEnt == "" {
-		return false
+		return nil, ErrInvalidMnemonic
 	}
-	binEntLen := len(binEnt)
+	csBitsLen := wordCount / 3
+	entBitsLen := len(binEnt) - csBitsLen
 
-	entropy := make([]byte, 0, wordCount+wordCount/3)
-	for i := 0; i < binEntLen-4; i += 8 {
+	entropy := make([]byte, 0, entBitsLen/8)
+	for i := 0; i < entBitsLen; i += 8 {
 		b, err := strconv.ParseInt(binEnt[i:i+8], 2, 32)
 		if err != nil {
-			return false
+			return nil, err
 		}
 		entropy = append(entropy, byte(b))
 	}
 
 	hash := sha256.New()
 	hash.Write(entropy)
-	csBitsLen := wordCount / 3
-	return binEnt[binEntLen-4:binEntLen] == fmt.Sprintf("%08b", hash.Sum(nil)[0])[:csBitsLen]
+	if binEnt[entBitsLen:] != fmt.Sprintf("%08b", hash.Sum(nil)[0])[:csBitsLen] {
+		return nil, ErrInvalidMnemonic
+	}
+	return entropy, nil
+}
+
+// indexMapping maps every word of lang to its index
+func indexMapping(lang Language) map[string]int {
+	wordMapping := make(map[string]int, 2048)
+	for idx, v := range lang.List() {
+		wordMapping[v] = idx
+	}
+	return wordMapping
 }
 
 func mnemonicToEntropy(wordList []string, wordMapping map[string]int) string {
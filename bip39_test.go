@@ -57,6 +57,22 @@ func TestIsMnemonicValid(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "EnglishTwentyFour",
+			args: args{
+				mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+				lang:     English,
+			},
+			want: true,
+		},
+		{
+			name: "EnglishTwentyFourChecksumError",
+			args: args{
+				mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo",
+				lang:     English,
+			},
+			want: false,
+		},
 		{
 			name: "ChineseSimplified",
 			args: args{
@@ -234,6 +250,77 @@ func TestMnemonicByEntropy(t *testing.T) {
 	}
 }
 
+func TestMnemonicToEntropy(t *testing.T) {
+	type args struct {
+		mnemonic string
+		lang     Language
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Twelve words",
+			args: args{
+				mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+				lang:     English,
+			},
+			want:    "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			wantErr: false,
+		},
+		{
+			name: "Eighteen words",
+			args: args{
+				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+				lang:     English,
+			},
+			want:    "000000000000000000000000000000000000000000000000",
+			wantErr: false,
+		},
+		{
+			name: "Twenty-four words",
+			args: args{
+				mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+				lang:     English,
+			},
+			want:    "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			wantErr: false,
+		},
+		{
+			name: "Checksum error",
+			args: args{
+				mnemonic: "legal winner thank year wave sausage worth useful legal winner thank year",
+				lang:     English,
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Wrong language",
+			args: args{
+				mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+				lang:     Spanish,
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MnemonicToEntropy(tt.args.mnemonic, tt.args.lang)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MnemonicToEntropy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("MnemonicToEntropy() = %x, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMnemonicToSeed(t *testing.T) {
 	type args struct {
 		mnemonic string
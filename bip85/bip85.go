@@ -0,0 +1,207 @@
+// Package bip85 implements BIP85 deterministic entropy from BIP32 keychains.
+//
+// One master mnemonic backs up any number of independent child secrets:
+// BIP39 mnemonics, WIF keys, xprvs, hex entropy and passwords. Each child
+// is derived from its own hardened path under m/83696968'.
+package bip85
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/bip32"
+	"github.com/adesight/bip39/wif"
+)
+
+// Purpose is the first path level of every BIP85 derivation
+const Purpose = 83696968
+
+// Applications numbers
+const (
+	AppBIP39     = 39
+	AppHDSeedWIF = 2
+	AppXPRV      = 32
+	AppHex       = 128169
+	AppBase64    = 707764
+	AppBase85    = 707785
+)
+
+const hardened = bip32.HardenedOffset
+
+var hmacKey = []byte("bip-entropy-from-k")
+
+// Error list
+var (
+	ErrPublicKey   = errors.New("BIP85 requires a private master key")
+	ErrLanguage    = errors.New("Language has no BIP85 index")
+	ErrHexLen      = errors.New("Invalid BIP85 hex length, should be 16 to 64 bytes")
+	ErrPasswordLen = errors.New("Invalid BIP85 password length")
+)
+
+// languageIndex maps a Language to its BIP85 language code
+var languageIndex = map[bip39.Language]uint32{
+	bip39.English:            0,
+	bip39.Japanese:           1,
+	bip39.Korean:             2,
+	bip39.Spanish:            3,
+	bip39.ChineseSimplified:  4,
+	bip39.ChineseTraditional: 5,
+	bip39.French:             6,
+	bip39.Italian:            7,
+}
+
+// LanguageIndex returns the BIP85 language code of lang
+func LanguageIndex(lang bip39.Language) (uint32, error) {
+	idx, has := languageIndex[lang]
+	if !has {
+		return 0, ErrLanguage
+	}
+	return idx, nil
+}
+
+// BIP85 derives child secrets from a master key
+type BIP85 struct {
+	master *bip32.Key
+}
+
+// New creates BIP85 from a private master key
+func New(master *bip32.Key) (*BIP85, error) {
+	if !master.IsPrivate() {
+		return nil, ErrPublicKey
+	}
+	return &BIP85{master: master}, nil
+}
+
+// NewFromMnemonic creates BIP85 from the seed of the master mnemonic.
+// param passwd can be empty string
+func NewFromMnemonic(mnemonic string, passwd string) (*BIP85, error) {
+	master, err := bip32.NewMasterKeyFromMnemonic(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+	return New(master)
+}
+
+// Entropy returns the 64 bytes of entropy at path, e.g. "m/83696968'/0'/0'"
+func (b *BIP85) Entropy(path string) ([]byte, error) {
+	indices, err := bip32.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return b.entropy(indices...)
+}
+
+func (b *BIP85) entropy(indices ...uint32) ([]byte, error) {
+	key, err := b.master.DeriveIndices(indices...)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha512.New, hmacKey)
+	mac.Write(key.PrivateKey())
+	return mac.Sum(nil), nil
+}
+
+func (b *BIP85) app(app uint32, params ...uint32) ([]byte, error) {
+	indices := []uint32{Purpose + hardened, app + hardened}
+	for _, v := range params {
+		if v >= hardened {
+			return nil, bip32.ErrInvalidPath
+		}
+		indices = append(indices, v+hardened)
+	}
+	return b.entropy(indices...)
+}
+
+// Mnemonic derives the child mnemonic at index
+func (b *BIP85) Mnemonic(lang bip39.Language, wordsLen int, index uint32) (string, error) {
+	if wordsLen < 12 || wordsLen > 24 || wordsLen%3 != 0 {
+		return "", bip39.ErrWordLen
+	}
+	langIdx, err := LanguageIndex(lang)
+	if err != nil {
+		return "", err
+	}
+	entropy, err := b.app(AppBIP39, langIdx, uint32(wordsLen), index)
+	if err != nil {
+		return "", err
+	}
+	// 12 words => 16 bytes, 24 words => 32 bytes
+	return bip39.NewMnemonicByEntropy(entropy[:wordsLen*4/3], lang)
+}
+
+// HDSeedWIF derives the child compressed mainnet WIF private key at index
+func (b *BIP85) HDSeedWIF(index uint32) (string, error) {
+	entropy, err := b.app(AppHDSeedWIF, index)
+	if err != nil {
+		return "", err
+	}
+	return wif.Encode(entropy[:32], true, false)
+}
+
+// XPRV derives the child master extended private key at index
+func (b *BIP85) XPRV(index uint32) (*bip32.Key, error) {
+	entropy, err := b.app(AppXPRV, index)
+	if err != nil {
+		return nil, err
+	}
+	// chain code comes first, unlike the BIP32 master key
+	return bip32.NewKey(entropy[32:], entropy[:32])
+}
+
+// Hex derives numBytes of child entropy at index, hex encoded
+func (b *BIP85) Hex(numBytes int, index uint32) (string, error) {
+	if numBytes < 16 || numBytes > 64 {
+		return "", ErrHexLen
+	}
+	entropy, err := b.app(AppHex, uint32(numBytes), index)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(entropy[:numBytes]), nil
+}
+
+// PasswordBase64 derives a base64 password of 20 to 86 characters at index
+func (b *BIP85) PasswordBase64(length int, index uint32) (string, error) {
+	if length < 20 || length > 86 {
+		return "", ErrPasswordLen
+	}
+	entropy, err := b.app(AppBase64, uint32(length), index)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(entropy)[:length], nil
+}
+
+// PasswordBase85 derives a base85 password of 10 to 80 characters at index
+func (b *BIP85) PasswordBase85(length int, index uint32) (string, error) {
+	if length < 10 || length > 80 {
+		return "", ErrPasswordLen
+	}
+	entropy, err := b.app(AppBase85, uint32(length), index)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase85(entropy)[:length], nil
+}
+
+// base85 alphabet of RFC 1924, as used by Python's base64.b85encode
+const base85Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// encodeBase85 encodes data whose length is a multiple of 4
+func encodeBase85(data []byte) string {
+	out := make([]byte, 0, len(data)/4*5)
+	for i := 0; i+4 <= len(data); i += 4 {
+		v := uint32(data[i])<<24 | uint32(data[i+1])<<16 | uint32(data[i+2])<<8 | uint32(data[i+3])
+		var chunk [5]byte
+		for j := 4; j >= 0; j-- {
+			chunk[j] = base85Alphabet[v%85]
+			v /= 85
+		}
+		out = append(out, chunk[:]...)
+	}
+	return string(out)
+}
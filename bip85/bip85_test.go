@@ -0,0 +1,138 @@
+package bip85
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/bip32"
+)
+
+const masterKey = "xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb"
+
+func newBIP85(t *testing.T) *BIP85 {
+	t.Helper()
+	master, err := bip32.ParseKey(masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(master)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEntropy(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "m/83696968'/0'/0'",
+			want: "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7",
+		},
+		{
+			path: "m/83696968'/0'/1'",
+			want: "70c6e3e8ebee8dc4c0dbba66076819bb8c09672527c4277ca8729532ad711872218f826919f6b67218adde99018a6df9095ab2b58d803b5b93ec9802085a690e",
+		},
+	}
+	b := newBIP85(t)
+	for _, tt := range tests {
+		got, err := b.Entropy(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tt.want {
+			t.Errorf("Entropy(%v) = %x, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMnemonic(t *testing.T) {
+	tests := []struct {
+		lang     bip39.Language
+		wordsLen int
+		want     string
+	}{
+		{
+			lang:     bip39.English,
+			wordsLen: 12,
+			want:     "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose",
+		},
+		{
+			lang:     bip39.English,
+			wordsLen: 18,
+			want:     "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token",
+		},
+		{
+			lang:     bip39.English,
+			wordsLen: 24,
+			want:     "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano",
+		},
+	}
+	b := newBIP85(t)
+	for _, tt := range tests {
+		got, err := b.Mnemonic(tt.lang, tt.wordsLen, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Mnemonic(%v) = %v, want %v", tt.wordsLen, got, tt.want)
+		}
+	}
+
+	// every language has an index and yields a valid mnemonic
+	for _, lang := range bip39.All() {
+		got, err := b.Mnemonic(lang, 15, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bip39.IsMnemonicValid(got, lang) {
+			t.Errorf("Mnemonic(%v) = %v is invalid", lang, got)
+		}
+	}
+
+	if _, err := b.Mnemonic(bip39.English, 13, 0); err != bip39.ErrWordLen {
+		t.Errorf("Mnemonic() error = %v, want %v", err, bip39.ErrWordLen)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	b := newBIP85(t)
+
+	if got, err := b.HDSeedWIF(0); err != nil || got != "Kzyv4uF39d4Jrw2W7UryTHwZr1zQVNk4dAFyqE6BuMrMh1Za7uhp" {
+		t.Errorf("HDSeedWIF() = %v, %v", got, err)
+	}
+
+	if got, err := b.XPRV(0); err != nil || got.String() != "xprv9s21ZrQH143K2srSbCSg4m4kLvPMzcWydgmKEnMmoZUurYuBuYG46c6P71UGXMzmriLzCCBvKQWBUv3vPB3m1SATMhp3uEjXHJ42jFg7myX" {
+		t.Errorf("XPRV() = %v, %v", got, err)
+	}
+
+	want := "492db4698cf3b73a5a24998aa3e9d7fa96275d85724a91e71aa2d645442f878555d078fd1f1f67e368976f04137b1f7a0d19232136ca50c44614af72b5582a5c"
+	if got, err := b.Hex(64, 0); err != nil || got != want {
+		t.Errorf("Hex() = %v, %v", got, err)
+	}
+	if _, err := b.Hex(15, 0); err != ErrHexLen {
+		t.Errorf("Hex() error = %v, want %v", err, ErrHexLen)
+	}
+
+	if got, err := b.PasswordBase64(21, 0); err != nil || got != "dKLoepugzdVJvdL56ogNV" {
+		t.Errorf("PasswordBase64() = %v, %v", got, err)
+	}
+	if got, err := b.PasswordBase85(12, 0); err != nil || got != "_s`{TW89)i4`" {
+		t.Errorf("PasswordBase85() = %v, %v", got, err)
+	}
+}
+
+func TestNewFromMnemonic(t *testing.T) {
+	for _, mnemonic := range []string{"", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"} {
+		if _, err := NewFromMnemonic(mnemonic, ""); err != bip39.ErrInvalidMnemonic {
+			t.Errorf("NewFromMnemonic() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+		}
+	}
+	master, _ := bip32.ParseKey(masterKey)
+	if _, err := New(master.Neuter()); err != ErrPublicKey {
+		t.Errorf("New() error = %v, want %v", err, ErrPublicKey)
+	}
+}
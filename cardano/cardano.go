@@ -0,0 +1,289 @@
+// Package cardano derives Cardano BIP32-Ed25519 keys from BIP39 mnemonics.
+//
+// Cardano does not use the MnemonicToSeed output: the root key comes either
+// from the entropy (Icarus, used by most software wallets) or from Ledger's
+// HMAC based scheme, see CIP-3. Child keys follow BIP32-Ed25519 along the
+// CIP-1852 paths m/1852'/1815'/account'/role/index.
+package cardano
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/bip32"
+)
+
+// CIP-1852 path levels
+const (
+	Purpose  = 1852
+	CoinType = 1815
+
+	RoleExternal = 0
+	RoleInternal = 1
+	RoleStaking  = 2
+)
+
+// HardenedOffset is the first hardened child index
+const HardenedOffset = bip32.HardenedOffset
+
+const (
+	// XPrvSize is the length of kL || kR || chain code
+	XPrvSize = 96
+	// XPubSize is the length of public key || chain code
+	XPubSize = 64
+)
+
+var ledgerSecret = []byte("ed25519 seed")
+
+// Error list
+var (
+	ErrInvalidKey     = errors.New("Invalid BIP32-Ed25519 key")
+	ErrHardenedPublic = errors.New("Cannot derive a hardened child from a public key")
+)
+
+// CIP1852Path returns the derivation path of an address key
+func CIP1852Path(account, role, index uint32) string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/%d/%d", Purpose, CoinType, account, role, index)
+}
+
+// XPrv is a BIP32-Ed25519 extended private key
+type XPrv struct {
+	kl, kr, cc [32]byte
+}
+
+// NewIcarusRootKey derives the Icarus root key from the entropy of a mnemonic.
+// param passwd can be empty string
+func NewIcarusRootKey(mnemonic string, passwd string, lang bip39.Language) (*XPrv, error) {
+	seed, err := bip39.IcarusSeedDeriver().Derive(mnemonic, passwd, lang)
+	if err != nil {
+		return nil, err
+	}
+	seed[0] &= 0xf8
+	seed[31] &= 0x1f
+	seed[31] |= 0x40
+	return NewXPrv(seed)
+}
+
+// NewLedgerRootKey derives the root key of a Ledger device from a mnemonic.
+// param passwd can be empty string
+func NewLedgerRootKey(mnemonic string, passwd string, lang bip39.Language) (*XPrv, error) {
+	if !bip39.IsMnemonicValid(mnemonic, lang) {
+		return nil, bip39.ErrInvalidMnemonic
+	}
+	seed, err := bip39.MnemonicToSeed(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, ledgerSecret)
+	mac.Write([]byte{0x01})
+	mac.Write(seed)
+	cc := mac.Sum(nil)
+
+	i := hmacSHA512(ledgerSecret, seed)
+	// rehash until the third highest bit of kL is clear
+	for i[31]&0x20 != 0 {
+		i = hmacSHA512(ledgerSecret, i)
+	}
+	i[0] &= 0xf8
+	i[31] &= 0x7f
+	i[31] |= 0x40
+	return NewXPrv(append(i, cc...))
+}
+
+// NewXPrv parses a 96 bytes kL || kR || chain code extended private key
+func NewXPrv(b []byte) (*XPrv, error) {
+	if len(b) != XPrvSize || b[0]&0x07 != 0 || b[31]&0x80 != 0 {
+		return nil, ErrInvalidKey
+	}
+	k := new(XPrv)
+	copy(k.kl[:], b[0:32])
+	copy(k.kr[:], b[32:64])
+	copy(k.cc[:], b[64:96])
+	return k, nil
+}
+
+// Bytes returns kL || kR || chain code
+func (k *XPrv) Bytes() []byte {
+	b := make([]byte, 0, XPrvSize)
+	b = append(b, k.kl[:]...)
+	b = append(b, k.kr[:]...)
+	return append(b, k.cc[:]...)
+}
+
+// PrivateKey returns the 64 bytes extended ed25519 private key kL || kR
+func (k *XPrv) PrivateKey() []byte {
+	return append(append([]byte(nil), k.kl[:]...), k.kr[:]...)
+}
+
+// ChainCode returns the chain code
+func (k *XPrv) ChainCode() []byte {
+	return append([]byte(nil), k.cc[:]...)
+}
+
+// PublicKey returns the 32 bytes ed25519 public key kL * B
+func (k *XPrv) PublicKey() []byte {
+	return scalarBaseMult(k.kl[:]).Bytes()
+}
+
+// Public returns the extended public key
+func (k *XPrv) Public() *XPub {
+	p := &XPub{cc: k.cc}
+	copy(p.pk[:], k.PublicKey())
+	return p
+}
+
+// Derive derives the descendant key at path, e.g. CIP1852Path(0, RoleExternal, 0)
+func (k *XPrv) Derive(path string) (*XPrv, error) {
+	indices, err := bip32.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, idx := range indices {
+		key = key.Child(idx)
+	}
+	return key, nil
+}
+
+// Child derives the child key at index.
+// Indices from HardenedOffset on are hardened
+func (k *XPrv) Child(index uint32) *XPrv {
+	var zData, cData []byte
+	if index >= HardenedOffset {
+		zData = append(append([]byte{0x00}, k.kl[:]...), k.kr[:]...)
+		cData = append(append([]byte{0x01}, k.kl[:]...), k.kr[:]...)
+	} else {
+		pk := k.PublicKey()
+		zData = append([]byte{0x02}, pk...)
+		cData = append([]byte{0x03}, pk...)
+	}
+	zData = binary.LittleEndian.AppendUint32(zData, index)
+	cData = binary.LittleEndian.AppendUint32(cData, index)
+
+	z := hmacSHA512(k.cc[:], zData)
+	c := hmacSHA512(k.cc[:], cData)
+
+	child := new(XPrv)
+	// kL' = 8 * zL + kL, kR' = zR + kR mod 2^256
+	zl8 := mul8(z[:28])
+	add256(child.kl[:], k.kl[:], zl8[:])
+	add256(child.kr[:], k.kr[:], z[32:])
+	copy(child.cc[:], c[32:])
+	return child
+}
+
+// XPub is a BIP32-Ed25519 extended public key
+type XPub struct {
+	pk, cc [32]byte
+}
+
+// NewXPub parses a 64 bytes public key || chain code extended public key
+func NewXPub(b []byte) (*XPub, error) {
+	if len(b) != XPubSize {
+		return nil, ErrInvalidKey
+	}
+	if _, err := new(edwards25519.Point).SetBytes(b[:32]); err != nil {
+		return nil, ErrInvalidKey
+	}
+	p := new(XPub)
+	copy(p.pk[:], b[:32])
+	copy(p.cc[:], b[32:])
+	return p, nil
+}
+
+// Bytes returns public key || chain code
+func (p *XPub) Bytes() []byte {
+	return append(append(make([]byte, 0, XPubSize), p.pk[:]...), p.cc[:]...)
+}
+
+// PublicKey returns the 32 bytes ed25519 public key
+func (p *XPub) PublicKey() []byte {
+	return append([]byte(nil), p.pk[:]...)
+}
+
+// ChainCode returns the chain code
+func (p *XPub) ChainCode() []byte {
+	return append([]byte(nil), p.cc[:]...)
+}
+
+// Derive derives the descendant public key at a path of soft indices
+func (p *XPub) Derive(path string) (*XPub, error) {
+	indices, err := bip32.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := p
+	for _, idx := range indices {
+		if key, err = key.Child(idx); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// Child derives the soft child public key at index
+func (p *XPub) Child(index uint32) (*XPub, error) {
+	if index >= HardenedOffset {
+		return nil, ErrHardenedPublic
+	}
+	zData := binary.LittleEndian.AppendUint32(append([]byte{0x02}, p.pk[:]...), index)
+	cData := binary.LittleEndian.AppendUint32(append([]byte{0x03}, p.pk[:]...), index)
+	z := hmacSHA512(p.cc[:], zData)
+	c := hmacSHA512(p.cc[:], cData)
+
+	point, err := new(edwards25519.Point).SetBytes(p.pk[:])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	// A' = A + 8 * zL * B
+	zl8 := mul8(z[:28])
+	point.Add(point, scalarBaseMult(zl8[:]))
+
+	child := new(XPub)
+	copy(child.pk[:], point.Bytes())
+	copy(child.cc[:], c[32:])
+	return child, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// scalarBaseMult multiplies the base point by a 32 bytes little endian
+// integer, which may exceed the group order
+func scalarBaseMult(k []byte) *edwards25519.Point {
+	var wide [64]byte
+	copy(wide[:], k)
+	s, _ := edwards25519.NewScalar().SetUniformBytes(wide[:])
+	return new(edwards25519.Point).ScalarBaseMult(s)
+}
+
+// mul8 returns 8 * zl as a 32 bytes little endian integer
+func mul8(zl []byte) (out [32]byte) {
+	var carry byte
+	for i, v := range zl {
+		out[i] = v<<3 | carry
+		carry = v >> 5
+	}
+	out[len(zl)] = carry
+	return out
+}
+
+// add256 sets dst = a + b mod 2^256, all little endian
+func add256(dst, a, b []byte) {
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		sum := uint16(a[i]) + uint16(b[i]) + carry
+		dst[i] = byte(sum)
+		carry = sum >> 8
+	}
+}
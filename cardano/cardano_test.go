@@ -0,0 +1,150 @@
+package cardano
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/internal/bech32"
+)
+
+func TestRootKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		scheme   func(string, string, bip39.Language) (*XPrv, error)
+		mnemonic string
+		want     string
+	}{
+		{
+			name:     "Icarus",
+			scheme:   NewIcarusRootKey,
+			mnemonic: "eight country switch draw meat scout mystery blade tip drift useless good keep usage title",
+			want:     "c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620",
+		},
+		{
+			name:     "Ledger",
+			scheme:   NewLedgerRootKey,
+			mnemonic: "recall grace sport punch exhibit mad harbor stand obey short width stem awkward used stairs wool ugly trap season stove worth toward congress jaguar",
+			want:     "a08cf85b564ecf3b947d8d4321fb96d70ee7bb760877e371899b14e2ccf88658104b884682b57efd97decbb318a45c05a527b9cc5c2f64f7352935a049ceea60680d52308194ccef2a18e6812b452a5815fbd7f5babc083856919aaf668fe7e4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := tt.scheme(tt.mnemonic, "", bip39.English)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(root.Bytes()); got != tt.want {
+				t.Errorf("root key = %v, want %v", got, tt.want)
+			}
+			if _, err := tt.scheme(tt.mnemonic, "", bip39.French); err != bip39.ErrInvalidMnemonic {
+				t.Errorf("root key error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+			}
+		})
+	}
+}
+
+func TestDerive(t *testing.T) {
+	root, err := NewIcarusRootKey("eight country switch draw meat scout mystery blade tip drift useless good keep usage title", "", bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := root.Derive("m/1852'/1815'/0'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path := CIP1852Path(0, RoleStaking, 0); path != "m/1852'/1815'/0'/2/0" {
+		t.Fatalf("CIP1852Path() = %v", path)
+	}
+
+	// soft children derive identically from the account public key
+	for _, role := range []uint32{RoleExternal, RoleInternal, RoleStaking} {
+		for _, idx := range []uint32{0, 1, 1000} {
+			prv := account.Child(role).Child(idx)
+			pub, err := account.Public().Child(role)
+			if err == nil {
+				pub, err = pub.Child(idx)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(prv.Public().Bytes(), pub.Bytes()) {
+				t.Errorf("role %v index %v: public derivation = %x, want %x", role, idx, pub.Bytes(), prv.Public().Bytes())
+			}
+		}
+	}
+
+	key, err := root.Derive(CIP1852Path(0, RoleExternal, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := account.Child(RoleExternal).Child(0); !bytes.Equal(key.Bytes(), want.Bytes()) {
+		t.Errorf("Derive() = %x, want %x", key.Bytes(), want.Bytes())
+	}
+	// derived keys stay valid extended keys
+	if _, err := NewXPrv(key.Bytes()); err != nil {
+		t.Errorf("NewXPrv() error = %v", err)
+	}
+	if _, err := NewXPub(key.Public().Bytes()); err != nil {
+		t.Errorf("NewXPub() error = %v", err)
+	}
+
+	if _, err := account.Public().Child(HardenedOffset); err != ErrHardenedPublic {
+		t.Errorf("Child() error = %v, want %v", err, ErrHardenedPublic)
+	}
+	if _, err := account.Public().Derive("m/0'/0"); err != ErrHardenedPublic {
+		t.Errorf("Derive() error = %v, want %v", err, ErrHardenedPublic)
+	}
+}
+
+func TestCIP1852Vectors(t *testing.T) {
+	// m/1852'/1815'/0'/0/0 vectors of the echovl/cardano-go wallet tests
+	tests := []struct {
+		mnemonic string
+		rootXsk  string
+		addrXsk0 string
+		addrXvk0 string
+	}{
+		{
+			mnemonic: "art forum devote street sure rather head chuckle guard poverty release quote oak craft enemy",
+			rootXsk:  "root_xsk1hretan5mml3tq2p0twkhq4tz4jvka7m2l94kfr6yghkyfar6m9wppc7h9unw6p65y23kakzct3695rs32z7vaw3r2lg9scmfj8ec5du3ufydu5yuquxcz24jlkjhsc9vsa4ufzge9s00fn398svhacse5sh85djs",
+			addrXsk0: "addr_xsk1fzgcl9km0mve2jwe8qxve364w6te9vpddhwpw5g8wnjlupmmm9wxpdda6jaglx7smwl6qd5xuzjcweeq8ykp0wg9hng4pg6eumwx2t90swaed7ehsa6j86qsw3fnl4thtemsng6vukmz6ddf3cnd4sfkzu74xjqg",
+			addrXvk0: "addr_xvk1fz009r4f0aceaemksezlca9cz8p8rewhaurvyvgg2ndnq9vwj3w6lqamjman0pm4y05pqazn8l2hwhnhpx35eedk9566nr3xmtqnv9ccm4zyu",
+		},
+		{
+			mnemonic: "churn shaft spoon second erode useless thrive burst group seed element sign scrub buffalo jelly grace neck useless",
+			rootXsk:  "root_xsk1az4qjp85qunj75m8krdvdygmv6u4ceqj8vnwaf38wfd69ycksa0fwt7n0cfp5zwmht9u0j9dzxxnfssjmkh4vn3dwxvddsle6m2vkm8q8p7addwq8y7q3s3eekd3ate40rfr6rpjakctcn2p54cpr3kjmy2hdej2",
+			addrXsk0: "addr_xsk17zj2lhjk379klp40xfzsad0yzygqe45uaggnkzf4ld3emgsksa00ftq88fnfjxg245kjjqcukyjfg4lwmf3r2qqymyyqennch3y8llyg0d629pdx0pp0l69lerjz75kxmk5e6cr2d82kafp7a25y0qy5fvj06w0u",
+			addrXvk0: "addr_xvk1fwgdh5vv6akdc3rjpeq57xxq4lc9m84xcrt6q827mq7u20wuw54gs7m552z6v7zzll5tlj8y9afvdhdfn4sx56w4d6jra64gg7qfgjc8e8sau",
+		},
+		{
+			mnemonic: "draft ability female child jump maid roof hurt below live topple paper exclude ordinary coach churn sunset emerge blame ketchup much",
+			rootXsk:  "root_xsk17zqw352yj02seytp9apunec55722k93crtplq8chgpfh7cx33dg4v2x3wpyhd9chhkknzhprztumrystkpfl5nyhyeuq0gnwf76r39u9l9q3z40hgf5jv6xn8unr5acs3yy8fxg35v5xjsw4kwvf5zfkvcn57fle",
+			addrXsk0: "addr_xsk1fz8tz0pdda8la0aqhadnzctw0p48zwygkgf4xyar2jjljm733dgkprs4sj8cxfwv9xtfddpdfvjlap0hhg9gd37pr0tp7ue48mh9cnfyy68k52f88z5vghezam30c3pcue6aewl4mqul6nvassxlenh3eqh822k2",
+			addrXvk0: "addr_xvk1x4dme9s2f5xxn77wgjhggqh73r6syy4nvjcdjklnaqrh48f6desjgf50dg5jww9gc30j9mhzl3zr3en4mjaltkpel4xempqdln80rjq5grmc7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mnemonic[:strings.IndexByte(tt.mnemonic, ' ')], func(t *testing.T) {
+			root, err := NewIcarusRootKey(tt.mnemonic, "", bip39.English)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := bech32.Encode("root_xsk", root.Bytes()); got != tt.rootXsk {
+				t.Errorf("root key = %v, want %v", got, tt.rootXsk)
+			}
+			key, err := root.Derive(CIP1852Path(0, RoleExternal, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := bech32.Encode("addr_xsk", key.Bytes()); got != tt.addrXsk0 {
+				t.Errorf("Derive() = %v, want %v", got, tt.addrXsk0)
+			}
+			if got := bech32.Encode("addr_xvk", key.Public().Bytes()); got != tt.addrXvk0 {
+				t.Errorf("Derive().Public() = %v, want %v", got, tt.addrXvk0)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/adesight/bip39"
+	"golang.org/x/term"
+)
+
+// Exit codes
+const (
+	exitOK = iota
+	exitInvalid
+	exitUsage
+	exitIO
+)
+
+const usage = `Usage: bip39 <command> [flags]
+
+Commands:
+  generate   generate a random mnemonic
+  entropy    encode entropy read from stdin as a mnemonic
+  validate   check a mnemonic read from stdin
+  seed       derive the seed of a mnemonic read from stdin
+
+Run "bip39 <command> -h" for the flags of a command.
+`
+
+// cli holds the streams of one invocation
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	reader *bufio.Reader
+}
+
+type command func(c *cli, args []string) int
+
+var commands = map[string]command{
+	"generate": (*cli).generate,
+	"entropy":  (*cli).entropy,
+	"validate": (*cli).validate,
+	"seed":     (*cli).seed,
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	cmd, has := commands[args[0]]
+	if !has {
+		if args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+			fmt.Fprint(stdout, usage)
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "bip39: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr, reader: bufio.NewReader(stdin)}
+	return cmd(c, args[1:])
+}
+
+func (c *cli) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("bip39 "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// parse parses flags and returns the exit code to stop with, if any
+func (c *cli) parse(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK, true
+		}
+		return exitUsage, true
+	}
+	if fs.NArg() != 0 {
+		c.errorf("unexpected arguments %q, secrets are read from stdin", fs.Args())
+		return exitUsage, true
+	}
+	return 0, false
+}
+
+func (c *cli) errorf(format string, args ...interface{}) {
+	fmt.Fprintf(c.stderr, "bip39: "+format+"\n", args...)
+}
+
+// readSecret reads one line from stdin, prompting without echo on a TTY
+func (c *cli) readSecret(prompt string) (string, error) {
+	if f, ok := c.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(c.stderr, prompt)
+		b, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(c.stderr)
+		return string(b), err
+	}
+	line, err := c.reader.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *cli) readMnemonic() (string, int) {
+	mnemonic, err := c.readSecret("Mnemonic: ")
+	if err != nil {
+		c.errorf("reading mnemonic: %v", err)
+		return "", exitIO
+	}
+	return strings.Join(strings.Fields(mnemonic), "\x20"), exitOK
+}
+
+func (c *cli) writeJSON(v interface{}) int {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		c.errorf("%v", err)
+		return exitIO
+	}
+	return exitOK
+}
+
+func (c *cli) writeLine(s string) int {
+	if _, err := fmt.Fprintln(c.stdout, s); err != nil {
+		return exitIO
+	}
+	return exitOK
+}
+
+type mnemonicOutput struct {
+	Mnemonic string         `json:"mnemonic"`
+	Language bip39.Language `json:"language"`
+	Words    int            `json:"words"`
+	Entropy  string         `json:"entropy"`
+}
+
+func (c *cli) writeMnemonic(format string, mnemonic string, lang bip39.Language) int {
+	switch format {
+	case "text":
+		return c.writeLine(mnemonic)
+	case "json":
+		entropy, err := bip39.MnemonicToEntropy(mnemonic, lang)
+		if err != nil {
+			c.errorf("%v", err)
+			return exitInvalid
+		}
+		return c.writeJSON(mnemonicOutput{
+			Mnemonic: mnemonic,
+			Language: lang,
+			Words:    len(strings.Fields(mnemonic)),
+			Entropy:  hex.EncodeToString(entropy),
+		})
+	}
+	c.errorf("unknown format %q", format)
+	return exitUsage
+}
+
+func (c *cli) generate(args []string) int {
+	fs := c.flagSet("generate")
+	words := fs.Int("words", 12, "number of words: 12, 15, 18, 21 or 24")
+	lang := bip39.English
+	fs.Var(&lang, "lang", "wordlist language name or code")
+	format := fs.String("format", "text", "output format: text or json")
+	if code, stop := c.parse(fs, args); stop {
+		return code
+	}
+
+	mnemonic, err := bip39.NewMnemonic(*words, lang)
+	if err != nil {
+		c.errorf("%v", err)
+		if err == bip39.ErrWordLen {
+			return exitUsage
+		}
+		return exitIO
+	}
+	return c.writeMnemonic(*format, mnemonic, lang)
+}
+
+func (c *cli) entropy(args []string) int {
+	fs := c.flagSet("entropy")
+	lang := bip39.English
+	fs.Var(&lang, "lang", "wordlist language name or code")
+	input := fs.String("input", "hex", "entropy encoding on stdin: hex or base64")
+	format := fs.String("format", "text", "output format: text or json")
+	if code, stop := c.parse(fs, args); stop {
+		return code
+	}
+
+	var decode func(string) ([]byte, error)
+	switch *input {
+	case "hex":
+		decode = hex.DecodeString
+	case "base64":
+		decode = base64.StdEncoding.DecodeString
+	default:
+		c.errorf("unknown input encoding %q", *input)
+		return exitUsage
+	}
+
+	s, err := c.readSecret("Entropy: ")
+	if err != nil {
+		c.errorf("reading entropy: %v", err)
+		return exitIO
+	}
+	entropy, err := decode(strings.TrimSpace(s))
+	if err != nil {
+		c.errorf("decoding entropy: %v", err)
+		return exitInvalid
+	}
+	mnemonic, err := bip39.NewMnemonicByEntropy(entropy, lang)
+	if err != nil {
+		c.errorf("%v", err)
+		return exitInvalid
+	}
+	return c.writeMnemonic(*format, mnemonic, lang)
+}
+
+func (c *cli) validate(args []string) int {
+	fs := c.flagSet("validate")
+	lang := bip39.English
+	fs.Var(&lang, "lang", "wordlist language name or code")
+	format := fs.String("format", "text", "output format: text or json")
+	if code, stop := c.parse(fs, args); stop {
+		return code
+	}
+	if *format != "text" && *format != "json" {
+		c.errorf("unknown format %q", *format)
+		return exitUsage
+	}
+
+	mnemonic, code := c.readMnemonic()
+	if code != exitOK {
+		return code
+	}
+	valid := bip39.IsMnemonicValid(mnemonic, lang)
+
+	if *format == "json" {
+		code = c.writeJSON(struct {
+			Valid bool `json:"valid"`
+		}{valid})
+	} else if valid {
+		code = c.writeLine("valid")
+	} else {
+		code = c.writeLine("invalid")
+	}
+	if code == exitOK && !valid {
+		return exitInvalid
+	}
+	return code
+}
+
+func (c *cli) seed(args []string) int {
+	fs := c.flagSet("seed")
+	lang := bip39.English
+	fs.Var(&lang, "lang", "wordlist language name or code")
+	passphrase := fs.Bool("passphrase", false, "read a passphrase after the mnemonic")
+	noValidate := fs.Bool("no-validate", false, "derive the seed even if the mnemonic checksum is wrong")
+	format := fs.String("format", "hex", "output format: hex, base64 or json")
+	if code, stop := c.parse(fs, args); stop {
+		return code
+	}
+	if *format != "hex" && *format != "base64" && *format != "json" {
+		c.errorf("unknown format %q", *format)
+		return exitUsage
+	}
+
+	mnemonic, code := c.readMnemonic()
+	if code != exitOK {
+		return code
+	}
+	if !*noValidate && !bip39.IsMnemonicValid(mnemonic, lang) {
+		c.errorf("%v", bip39.ErrInvalidMnemonic)
+		return exitInvalid
+	}
+	var passwd string
+	if *passphrase {
+		var err error
+		if passwd, err = c.readSecret("Passphrase: "); err != nil {
+			c.errorf("reading passphrase: %v", err)
+			return exitIO
+		}
+	}
+
+	seed, err := bip39.MnemonicToSeed(mnemonic, passwd)
+	if err != nil {
+		c.errorf("%v", err)
+		return exitInvalid
+	}
+	switch *format {
+	case "base64":
+		return c.writeLine(base64.StdEncoding.EncodeToString(seed))
+	case "json":
+		return c.writeJSON(struct {
+			Seed string `json:"seed"`
+		}{hex.EncodeToString(seed)})
+	}
+	return c.writeLine(hex.EncodeToString(seed))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     string
+		wantCode int
+	}{
+		{
+			name:     "no command",
+			wantCode: exitUsage,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"foo"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "entropy",
+			args:     []string{"entropy"},
+			stdin:    "00000000000000000000000000000000\n",
+			want:     mnemonic + "\n",
+			wantCode: exitOK,
+		},
+		{
+			name:     "entropy base64 json",
+			args:     []string{"entropy", "-input", "base64", "-format", "json"},
+			stdin:    "AAAAAAAAAAAAAAAAAAAAAA==",
+			want:     `"entropy": "00000000000000000000000000000000"`,
+			wantCode: exitOK,
+		},
+		{
+			name:     "entropy language code",
+			args:     []string{"entropy", "-lang", "ja", "-format", "json"},
+			stdin:    "00000000000000000000000000000000",
+			want:     `"language": "japanese"`,
+			wantCode: exitOK,
+		},
+		{
+			name:     "entropy invalid length",
+			args:     []string{"entropy"},
+			stdin:    "0000",
+			wantCode: exitInvalid,
+		},
+		{
+			name:     "validate",
+			args:     []string{"validate"},
+			stdin:    mnemonic + "\n",
+			want:     "valid\n",
+			wantCode: exitOK,
+		},
+		{
+			name:     "validate invalid",
+			args:     []string{"validate", "-format", "json"},
+			stdin:    strings.Replace(mnemonic, "about", "abandon", 1),
+			want:     `"valid": false`,
+			wantCode: exitInvalid,
+		},
+		{
+			name:     "validate unknown language",
+			args:     []string{"validate", "-lang", "klingon"},
+			stdin:    mnemonic,
+			wantCode: exitUsage,
+		},
+		{
+			name:     "seed with passphrase",
+			args:     []string{"seed", "-passphrase"},
+			stdin:    mnemonic + "\nTREZOR\n",
+			want:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04\n",
+			wantCode: exitOK,
+		},
+		{
+			name:     "seed base64",
+			args:     []string{"seed", "-format", "base64"},
+			stdin:    mnemonic,
+			want:     "XrALvdzwaQhIiairkVVWgWX1xFPMuF5wgRqu1vbaX8GaWsQLOJzTcNCGIG3siqbEPa6maQ8grT2NSLLSzp445A==\n",
+			wantCode: exitOK,
+		},
+		{
+			name:     "seed rejects argv secrets",
+			args:     []string{"seed", "abandon"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "generate word count",
+			args:     []string{"generate", "-words", "13"},
+			wantCode: exitUsage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() = %v, want %v, stderr %q", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("run() output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for _, lang := range []string{"english", "japanese", "chinese_traditional"} {
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"generate", "-words", "24", "-lang", lang}, nil, &stdout, &stderr); code != exitOK {
+			t.Fatalf("generate -lang %v = %v, stderr %q", lang, code, stderr.String())
+		}
+
+		var validated bytes.Buffer
+		code := run([]string{"validate", "-lang", lang}, &stdout, &validated, &stderr)
+		if code != exitOK || validated.String() != "valid\n" {
+			t.Errorf("validate -lang %v = %v, %q", lang, code, validated.String())
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Command bip39 generates, validates and converts BIP39 mnemonics.
+//
+// Secrets such as mnemonics, entropy and passphrases are never taken from
+// the command line, where they would end up in shell history and process
+// listings. They are read from stdin, or prompted for when stdin is a TTY.
+//
+// Usage:
+//
+//	bip39 generate [-words 12] [-lang english] [-format text|json]
+//	bip39 entropy  [-lang english] [-input hex|base64] [-format text|json]
+//	bip39 validate [-lang english] [-format text|json]
+//	bip39 seed     [-lang english] [-passphrase] [-format hex|base64|json]
+//
+// Exit codes: 0 success, 1 invalid mnemonic or entropy, 2 usage error,
+// 3 I/O error.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,74 @@
+package codex32
+
+import "strings"
+
+// Generators of the BCH codes of BIP93, written as bech32 strings.
+// Short strings use a 13 character checksum, long strings 15 characters.
+var (
+	shortGenerator = [5]string{
+		"em3gqeeelmcss", "mltsqmmmhleff", "lhkfqlll8hmjj", "h89jqhhhw8ldd", "8w2dq888uwh66",
+	}
+	longGenerator = [5]string{
+		"02e6fe4xh4x9kyh", "75majmrv8rv29g8", "4plndlxcwxc52sw", "rzh06hveuvep5fu", "xy87a8cm3cmzpj3",
+	}
+
+	shortConst = "secretshare32"
+	longConst  = "secretshare32ex"
+)
+
+// polymod runs the checksum over the "ms" prefix and data.
+// The residue is kept as one GF(32) element per checksum character.
+func polymod(data []byte, generator [5]string) []byte {
+	residue := make([]byte, len(generator[0]))
+	residue[len(residue)-1] = 1
+
+	values := make([]byte, 0, 2*len(hrp)+1+len(data))
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	values = append(values, data...)
+
+	for _, v := range values {
+		b := residue[0]
+		copy(residue, residue[1:])
+		residue[len(residue)-1] = v
+		for i, gen := range generator {
+			if b>>uint(i)&1 != 0 {
+				for j := range residue {
+					residue[j] ^= byte(strings.IndexByte(charset, gen[j]))
+				}
+			}
+		}
+	}
+	return residue
+}
+
+func verifyChecksum(data []byte) bool {
+	generator, target := shortGenerator, shortConst
+	if len(data) >= minLongDataLen {
+		generator, target = longGenerator, longConst
+	}
+	residue := polymod(data, generator)
+	for i := range residue {
+		if charset[residue[i]] != target[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func createChecksum(data []byte) []byte {
+	generator, target := shortGenerator, shortConst
+	if len(data) > maxShortDataLen-shortChecksum {
+		generator, target = longGenerator, longConst
+	}
+	residue := polymod(append(append([]byte(nil), data...), make([]byte, len(target))...), generator)
+	for i := range residue {
+		residue[i] ^= byte(strings.IndexByte(charset, target[i]))
+	}
+	return residue
+}
@@ -0,0 +1,315 @@
+// Package codex32 implements BIP93 codex32 checksummed secret shares.
+//
+// A codex32 string is a bech32-like string with the "ms" prefix holding
+// either a master secret (share index "s") or one of the shares of a
+// k-of-n split of it. Shares are recombined by Lagrange interpolation over
+// GF(32), which is simple enough to be carried out by hand with volvelles.
+package codex32
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+
+	"github.com/adesight/bip39"
+)
+
+const (
+	hrp       = "ms"
+	separator = '1'
+	charset   = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// SecretIndex is the share index of the master secret
+	SecretIndex = 's'
+
+	// shareIndices are handed out to shares by Split, in order
+	shareIndices = "acdefghjklmnpqrtuvwxyz023456789"
+
+	headerLen       = 6 // threshold, identifier and share index
+	shortChecksum   = 13
+	longChecksum    = 15
+	maxShortDataLen = 93
+	minLongDataLen  = 96
+	maxLongDataLen  = 124
+	minPayloadLen   = 16
+)
+
+// Error list
+var (
+	ErrInvalidLength     = errors.New("Invalid codex32 string length")
+	ErrInvalidCase       = errors.New("Invalid codex32 mixed case string")
+	ErrInvalidPrefix     = errors.New("Invalid codex32 prefix")
+	ErrInvalidChar       = errors.New("Invalid codex32 character")
+	ErrInvalidChecksum   = errors.New("Invalid codex32 checksum")
+	ErrInvalidThreshold  = errors.New("Invalid codex32 threshold")
+	ErrInvalidIdentifier = errors.New("Invalid codex32 identifier")
+	ErrInvalidShareIndex = errors.New("Invalid codex32 share index")
+	ErrInvalidPayload    = errors.New("Invalid codex32 payload length")
+	ErrMismatchedShares  = errors.New("Codex32 shares do not belong together")
+	ErrDuplicateShare    = errors.New("Duplicate codex32 share index")
+	ErrNotEnoughShares   = errors.New("Not enough codex32 shares")
+)
+
+// Share is a codex32 string, either a master secret or a share of it
+type Share struct {
+	// data is the 5-bit values after the "ms1" prefix, checksum included
+	data []byte
+}
+
+// Parse decodes and verifies a codex32 string
+func Parse(s string) (*Share, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return nil, ErrInvalidCase
+	}
+	s = strings.ToLower(s)
+
+	pos := strings.LastIndexByte(s, separator)
+	if pos < 0 || s[:pos] != hrp {
+		return nil, ErrInvalidPrefix
+	}
+
+	data := make([]byte, 0, len(s)-pos-1)
+	for _, c := range s[pos+1:] {
+		v := strings.IndexRune(charset, c)
+		if v < 0 {
+			return nil, ErrInvalidChar
+		}
+		data = append(data, byte(v))
+	}
+
+	var checksumLen int
+	switch n := len(data); {
+	case n > maxShortDataLen && n < minLongDataLen, n > maxLongDataLen:
+		return nil, ErrInvalidLength
+	case n >= minLongDataLen:
+		checksumLen = longChecksum
+	default:
+		checksumLen = shortChecksum
+	}
+	payloadLen := len(data) - headerLen - checksumLen
+	if payloadLen*5/8 < minPayloadLen || payloadLen*5%8 > 4 {
+		return nil, ErrInvalidPayload
+	}
+	if !verifyChecksum(data) {
+		return nil, ErrInvalidChecksum
+	}
+
+	share := &Share{data: data}
+	switch threshold := share.Threshold(); {
+	case threshold < 0 || threshold == 1 || threshold > 9:
+		return nil, ErrInvalidThreshold
+	case threshold == 0 && share.Index() != SecretIndex:
+		return nil, ErrInvalidShareIndex
+	}
+	return share, nil
+}
+
+// NewSecret encodes a master secret as a codex32 string with share index "s".
+// param threshold is 0 for a secret which will not be split, otherwise 2 to 9
+func NewSecret(secret []byte, id string, threshold int) (*Share, error) {
+	if threshold < 0 || threshold == 1 || threshold > 9 {
+		return nil, ErrInvalidThreshold
+	}
+	if len(secret) < minPayloadLen || len(secret) > 64 {
+		return nil, ErrInvalidPayload
+	}
+	return newShare(threshold, id, SecretIndex, secret)
+}
+
+// FromMnemonic encodes the entropy of a BIP39 mnemonic as a codex32 secret
+func FromMnemonic(mnemonic string, lang bip39.Language, id string, threshold int) (*Share, error) {
+	entropy, err := bip39.MnemonicToEntropy(mnemonic, lang)
+	if err != nil {
+		return nil, err
+	}
+	return NewSecret(entropy, id, threshold)
+}
+
+func newShare(threshold int, id string, index byte, payload []byte) (*Share, error) {
+	id = strings.ToLower(id)
+	if len(id) != 4 {
+		return nil, ErrInvalidIdentifier
+	}
+	data := make([]byte, 0, headerLen+(len(payload)*8+4)/5+longChecksum)
+	data = append(data, byte(strings.IndexByte(charset, byte('0'+threshold))))
+	for i := 0; i < len(id); i++ {
+		v := strings.IndexByte(charset, id[i])
+		if v < 0 {
+			return nil, ErrInvalidIdentifier
+		}
+		data = append(data, byte(v))
+	}
+	data = append(data, byte(strings.IndexByte(charset, index)))
+	data = append(data, toBase32(payload)...)
+	return &Share{data: append(data, createChecksum(data)...)}, nil
+}
+
+// Threshold returns the number of shares needed to recover the secret
+func (s *Share) Threshold() int {
+	return int(charset[s.data[0]] - '0')
+}
+
+// Identifier returns the four character identifier shared by all shares
+func (s *Share) Identifier() string {
+	return s.encode(s.data[1:5])
+}
+
+// Index returns the share index character, SecretIndex for the secret
+func (s *Share) Index() byte {
+	return charset[s.data[5]]
+}
+
+// Payload returns the data carried by the share, the master secret for
+// share index "s"
+func (s *Share) Payload() []byte {
+	return fromBase32(s.data[headerLen : len(s.data)-s.checksumLen()])
+}
+
+// ToMnemonic encodes the master secret as a BIP39 mnemonic.
+// Only 16 to 32 byte secrets have a mnemonic form.
+func (s *Share) ToMnemonic(lang bip39.Language) (string, error) {
+	if s.Index() != SecretIndex {
+		return "", ErrInvalidShareIndex
+	}
+	return bip39.NewMnemonicByEntropy(s.Payload(), lang)
+}
+
+// String returns the lowercase codex32 string
+func (s *Share) String() string {
+	return hrp + string(separator) + s.encode(s.data)
+}
+
+func (s *Share) encode(data []byte) string {
+	var buf strings.Builder
+	for _, v := range data {
+		buf.WriteByte(charset[v])
+	}
+	return buf.String()
+}
+
+func (s *Share) checksumLen() int {
+	if len(s.data) >= minLongDataLen {
+		return longChecksum
+	}
+	return shortChecksum
+}
+
+// Split creates n shares of secret, any Threshold() of which recover it.
+// The first Threshold()-1 shares are random and the rest are derived
+// from them and the secret by interpolation.
+func Split(secret *Share, n int) ([]*Share, error) {
+	k := secret.Threshold()
+	if secret.Index() != SecretIndex {
+		return nil, ErrInvalidShareIndex
+	}
+	if k < 2 || n < k || n > len(shareIndices) {
+		return nil, ErrInvalidThreshold
+	}
+
+	payloadLen := len(secret.Payload())
+	base := []*Share{secret}
+	for i := 0; i < k-1; i++ {
+		payload := make([]byte, payloadLen)
+		if _, err := rand.Read(payload); err != nil {
+			return nil, err
+		}
+		share, err := newShare(k, secret.Identifier(), shareIndices[i], payload)
+		if err != nil {
+			return nil, err
+		}
+		base = append(base, share)
+	}
+
+	shares := append([]*Share(nil), base[1:]...)
+	for i := k - 1; i < n; i++ {
+		share, err := Interpolate(base, shareIndices[i])
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+// Recover combines at least Threshold() shares into the master secret
+func Recover(shares []*Share) (*Share, error) {
+	return Interpolate(shares, SecretIndex)
+}
+
+// Interpolate derives the share with the given index from at least
+// Threshold() other shares of the same secret
+func Interpolate(shares []*Share, index byte) (*Share, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	target := strings.IndexByte(charset, index)
+	if target < 0 {
+		return nil, ErrInvalidShareIndex
+	}
+
+	first := shares[0]
+	if first.Threshold() == 0 {
+		return nil, ErrInvalidThreshold
+	}
+	seen := make(map[byte]bool, len(shares))
+	xs := make([]byte, 0, len(shares))
+	for _, share := range shares {
+		if len(share.data) != len(first.data) || share.Threshold() != first.Threshold() ||
+			share.Identifier() != first.Identifier() {
+			return nil, ErrMismatchedShares
+		}
+		x := share.data[5]
+		if seen[x] {
+			return nil, ErrDuplicateShare
+		}
+		seen[x] = true
+		xs = append(xs, x)
+		if int(x) == target {
+			return share, nil
+		}
+	}
+	if len(shares) < first.Threshold() {
+		return nil, ErrNotEnoughShares
+	}
+
+	weights := lagrange(xs, byte(target))
+	data := make([]byte, len(first.data))
+	for i := range data {
+		for j, share := range shares {
+			data[i] ^= gfMul(weights[j], share.data[i])
+		}
+	}
+	return &Share{data: data}, nil
+}
+
+func toBase32(b []byte) []byte {
+	res := make([]byte, 0, (len(b)*8+4)/5)
+	var acc, bits uint
+	for _, v := range b {
+		acc = acc<<8 | uint(v)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			res = append(res, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		res = append(res, byte(acc<<(5-bits)&31))
+	}
+	return res
+}
+
+func fromBase32(data []byte) []byte {
+	res := make([]byte, 0, len(data)*5/8)
+	var acc, bits uint
+	for _, v := range data {
+		acc = acc<<5 | uint(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			res = append(res, byte(acc>>bits))
+		}
+	}
+	// any leftover bits are padding
+	return res
+}
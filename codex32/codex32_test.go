@@ -0,0 +1,186 @@
+package codex32
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		threshold int
+		id        string
+		index     byte
+		payload   string
+		wantErr   error
+	}{
+		{
+			name:      "secret",
+			s:         "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw",
+			threshold: 0,
+			id:        "test",
+			index:     's',
+			payload:   "318c6318c6318c6318c6318c6318c631",
+		},
+		{
+			name:      "share uppercase",
+			s:         "MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM",
+			threshold: 2,
+			id:        "name",
+			index:     'a',
+			payload:   "d1808e096b35b209ca12132b264662a5",
+		},
+		{
+			name:      "long secret",
+			s:         "MS100C8VSM32ZXFGUHPCHTLUPZRY9X8GF2TVDW0S3JN54KHCE6MUA7LQPZYGSFJD6AN074RXVCEMLH8WU3TK925ACDEFGHJKLMNPQRSTUVWXY06FHPV80UNDVARHRAK",
+			threshold: 0,
+			id:        "0c8v",
+			index:     's',
+			payload:   "dc5423251cb87175ff8110c8531d0952d8d73e1194e95b5f19d6f9df7c01111104c9baecdfea8cccc677fb9ddc8aec5553b86e528bcadfdcc201c17c638c47e9",
+		},
+		{
+			name:    "bad checksum",
+			s:       "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlq",
+			wantErr: ErrInvalidChecksum,
+		},
+		{
+			name:    "mixed case",
+			s:       "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczLW",
+			wantErr: ErrInvalidCase,
+		},
+		{
+			name:    "prefix",
+			s:       "mz10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw",
+			wantErr: ErrInvalidPrefix,
+		},
+		{
+			name:    "character",
+			s:       "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlb",
+			wantErr: ErrInvalidChar,
+		},
+		{
+			name:    "short",
+			s:       "ms10testsxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw",
+			wantErr: ErrInvalidPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.s)
+			if err != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Threshold() != tt.threshold || got.Identifier() != tt.id || got.Index() != tt.index {
+				t.Errorf("Parse() = %v/%v/%c, want %v/%v/%c", got.Threshold(), got.Identifier(), got.Index(),
+					tt.threshold, tt.id, tt.index)
+			}
+			if payload := hex.EncodeToString(got.Payload()); tt.index == SecretIndex && payload != tt.payload {
+				t.Errorf("Share.Payload() = %v, want %v", payload, tt.payload)
+			}
+			if got.String() != strings.ToLower(tt.s) {
+				t.Errorf("Share.String() = %v, want %v", got.String(), strings.ToLower(tt.s))
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var shares []*Share
+	for _, s := range []string{
+		"MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM",
+		"MS12NAMECACDEFGHJKLMNPQRSTUVWXYZ023FTR2GDZMPY6PN",
+	} {
+		share, err := Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shares = append(shares, share)
+	}
+
+	if _, err := Recover(shares[:1]); err != ErrNotEnoughShares {
+		t.Errorf("Recover() error = %v, want %v", err, ErrNotEnoughShares)
+	}
+	if _, err := Recover([]*Share{shares[0], shares[0]}); err != ErrDuplicateShare {
+		t.Errorf("Recover() error = %v, want %v", err, ErrDuplicateShare)
+	}
+
+	secret, err := Recover(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ms12names6xqguzttxkeqnjsjzv4jv3nz5k3kwgsphuh6evw"; secret.String() != want {
+		t.Errorf("Recover() = %v, want %v", secret, want)
+	}
+	if got := hex.EncodeToString(secret.Payload()); got != "d1808e096b35b209ca12132b264662a5" {
+		t.Errorf("Share.Payload() = %v", got)
+	}
+
+	share, err := Interpolate(shares, 'd')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(share.String()); err != nil {
+		t.Errorf("Interpolate() produced invalid share %v: %v", share, err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	secret, err := NewSecret(make([]byte, 32), "cash", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := Split(secret, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("Split() returned %v shares, want 5", len(shares))
+	}
+	for _, share := range shares {
+		if _, err := Parse(share.String()); err != nil {
+			t.Errorf("Split() produced invalid share %v: %v", share, err)
+		}
+	}
+
+	got, err := Recover([]*Share{shares[4], shares[1], shares[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != secret.String() {
+		t.Errorf("Recover() = %v, want %v", got, secret)
+	}
+
+	if _, err := Split(secret, 2); err != ErrInvalidThreshold {
+		t.Errorf("Split() error = %v, want %v", err, ErrInvalidThreshold)
+	}
+}
+
+func TestMnemonic(t *testing.T) {
+	mnemonic := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	secret, err := FromMnemonic(mnemonic, bip39.English, "leet", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(secret.Payload()); got != "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f" {
+		t.Errorf("Share.Payload() = %v", got)
+	}
+
+	parsed, err := Parse(secret.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parsed.ToMnemonic(bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != mnemonic {
+		t.Errorf("Share.ToMnemonic() = %v, want %v", got, mnemonic)
+	}
+}
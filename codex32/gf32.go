@@ -0,0 +1,55 @@
+package codex32
+
+// GF(32) is built from the polynomial x^5 + x^3 + 1, as in bech32.
+var (
+	gfExp [31]byte
+	gfLog [32]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := range gfExp {
+		gfExp[i] = x
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&32 != 0 {
+			x ^= 0x29
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])+int(gfLog[b]))%31]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[(31-int(gfLog[a]))%31]
+}
+
+// lagrange returns the weights which interpolate the values at xs to x.
+// In characteristic 2 subtraction is XOR.
+func lagrange(xs []byte, x byte) []byte {
+	n := byte(1)
+	c := make([]byte, 0, len(xs))
+	for _, i := range xs {
+		n = gfMul(n, i^x)
+		m := byte(1)
+		for _, j := range xs {
+			if i == j {
+				m = gfMul(m, x^j)
+			} else {
+				m = gfMul(m, i^j)
+			}
+		}
+		c = append(c, m)
+	}
+
+	weights := make([]byte, len(xs))
+	for k, m := range c {
+		weights[k] = gfMul(n, gfInv(m))
+	}
+	return weights
+}
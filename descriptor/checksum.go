@@ -0,0 +1,91 @@
+package descriptor
+
+import "strings"
+
+const (
+	inputCharset    = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	checksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	checksumLen     = 8
+)
+
+var generator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+func polymod(symbols []uint64) uint64 {
+	chk := uint64(1)
+	for _, v := range symbols {
+		top := chk >> 35
+		chk = (chk&0x7ffffffff)<<5 ^ v
+		for i, g := range generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+// expand maps every character to its 5 low bits, and every group of three
+// characters to one more symbol of their high bits
+func expand(s string) ([]uint64, error) {
+	symbols := make([]uint64, 0, len(s)+len(s)/3+1+checksumLen)
+	groups := make([]uint64, 0, 3)
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(inputCharset, s[i])
+		if v < 0 {
+			return nil, ErrInvalidChar
+		}
+		symbols = append(symbols, uint64(v&31))
+		groups = append(groups, uint64(v>>5))
+		if len(groups) == 3 {
+			symbols = append(symbols, groups[0]*9+groups[1]*3+groups[2])
+			groups = groups[:0]
+		}
+	}
+	switch len(groups) {
+	case 1:
+		symbols = append(symbols, groups[0])
+	case 2:
+		symbols = append(symbols, groups[0]*3+groups[1])
+	}
+	return symbols, nil
+}
+
+// Checksum returns the BIP380 checksum of a descriptor without one
+func Checksum(desc string) (string, error) {
+	symbols, err := expand(desc)
+	if err != nil {
+		return "", err
+	}
+	chk := polymod(append(symbols, make([]uint64, checksumLen)...)) ^ 1
+
+	out := make([]byte, checksumLen)
+	for i := range out {
+		out[i] = checksumCharset[(chk>>uint(5*(checksumLen-1-i)))&31]
+	}
+	return string(out), nil
+}
+
+// AddChecksum appends "#" and the checksum to a descriptor
+func AddChecksum(desc string) (string, error) {
+	chk, err := Checksum(desc)
+	if err != nil {
+		return "", err
+	}
+	return desc + "#" + chk, nil
+}
+
+// VerifyChecksum checks the checksum of a descriptor ending in "#checksum"
+func VerifyChecksum(desc string) error {
+	pos := strings.LastIndexByte(desc, '#')
+	if pos < 0 || len(desc)-pos-1 != checksumLen {
+		return ErrInvalidChecksum
+	}
+	chk, err := Checksum(desc[:pos])
+	if err != nil {
+		return err
+	}
+	if chk != desc[pos+1:] {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
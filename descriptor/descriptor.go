@@ -0,0 +1,172 @@
+// Package descriptor exports BIP380 output descriptors and watch-only wallet
+// files for the accounts of a mnemonic.
+//
+// Descriptors carry the key origin [fingerprint/path] and a checksum, so
+// Bitcoin Core, Sparrow and other wallets import them without manual xpub
+// copying. The JSON exports follow Coldcard's generic format, Specter and
+// the Electrum style keystore read by BlueWallet.
+package descriptor
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/adesight/bip39/bip32"
+)
+
+// Error list
+var (
+	ErrInvalidChar       = errors.New("Invalid descriptor character")
+	ErrInvalidChecksum   = errors.New("Invalid descriptor checksum")
+	ErrInvalidScriptType = errors.New("Invalid script type")
+	ErrInvalidNetwork    = errors.New("Invalid network")
+)
+
+// ScriptType is the output script of an account
+type ScriptType uint8
+
+// ScriptType list
+const (
+	PKH    ScriptType = iota // legacy, BIP44
+	SHWPKH                   // nested segwit, BIP49
+	WPKH                     // native segwit, BIP84
+	TR                       // taproot, BIP86
+)
+
+var scriptTypes = [...]struct {
+	purpose uint32
+	name    string // Coldcard's name of the address format
+	format  string // descriptor with the key in place of %s
+}{
+	PKH:    {44, "p2pkh", "pkh(%s)"},
+	SHWPKH: {49, "p2sh-p2wpkh", "sh(wpkh(%s))"},
+	WPKH:   {84, "p2wpkh", "wpkh(%s)"},
+	TR:     {86, "p2tr", "tr(%s)"},
+}
+
+func (t ScriptType) valid() bool {
+	return int(t) < len(scriptTypes)
+}
+
+// Purpose returns the BIP43 purpose of the script type, 0 if unknown
+func (t ScriptType) Purpose() uint32 {
+	if !t.valid() {
+		return 0
+	}
+	return scriptTypes[t].purpose
+}
+
+// Network selects the coin type and the extended key versions
+type Network uint8
+
+// Network list
+const (
+	Mainnet Network = iota
+	Testnet
+)
+
+// SLIP-132 extended public key versions
+var (
+	mainnetYpub = [4]byte{0x04, 0x9d, 0x7c, 0xb2}
+	mainnetZpub = [4]byte{0x04, 0xb2, 0x47, 0x46}
+	testnetUpub = [4]byte{0x04, 0x4a, 0x52, 0x62}
+	testnetVpub = [4]byte{0x04, 0x5f, 0x1c, 0xf6}
+)
+
+// Account is the watch-only part of a BIP44 style account
+type Account struct {
+	Type        ScriptType
+	Network     Network
+	Index       uint32
+	Fingerprint [4]byte // of the master key
+	XPub        *bip32.Key
+}
+
+// NewAccount derives an account from a mnemonic.
+// param passwd can be empty string
+func NewAccount(mnemonic string, passwd string, typ ScriptType, index uint32, net Network) (*Account, error) {
+	master, err := bip32.NewMasterKeyFromMnemonic(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountFromKey(master, typ, index, net)
+}
+
+// NewAccountFromKey derives an account from a master private key
+func NewAccountFromKey(master *bip32.Key, typ ScriptType, index uint32, net Network) (*Account, error) {
+	if !typ.valid() {
+		return nil, ErrInvalidScriptType
+	}
+	if net > Testnet {
+		return nil, ErrInvalidNetwork
+	}
+	if index >= bip32.HardenedOffset {
+		return nil, bip32.ErrInvalidPath
+	}
+
+	key, err := master.DeriveIndices(
+		typ.Purpose()+bip32.HardenedOffset,
+		uint32(net)+bip32.HardenedOffset,
+		index+bip32.HardenedOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	xpub := key.Neuter()
+	if net == Testnet {
+		xpub = xpub.SetVersion(bip32.TestnetPublic)
+	}
+
+	a := &Account{Type: typ, Network: net, Index: index, XPub: xpub}
+	copy(a.Fingerprint[:], master.Fingerprint())
+	return a, nil
+}
+
+// Path returns the account derivation path, e.g. "m/84h/0h/0h"
+func (a *Account) Path() string {
+	return fmt.Sprintf("m/%dh/%dh/%dh", a.Type.Purpose(), a.Network, a.Index)
+}
+
+// keyOrigin returns the key with its origin, e.g. "[73c5da0a/84h/0h/0h]xpub..."
+func (a *Account) keyOrigin() string {
+	return fmt.Sprintf("[%s%s]%s", hex.EncodeToString(a.Fingerprint[:]), a.Path()[1:], a.XPub)
+}
+
+func (a *Account) descriptor(suffix string) string {
+	desc := fmt.Sprintf(scriptTypes[a.Type].format, a.keyOrigin()+suffix)
+	// the input charset covers every character used above
+	desc, _ = AddChecksum(desc)
+	return desc
+}
+
+// Receive returns the descriptor of the receive addresses .../0/*
+func (a *Account) Receive() string {
+	return a.descriptor("/0/*")
+}
+
+// Change returns the descriptor of the change addresses .../1/*
+func (a *Account) Change() string {
+	return a.descriptor("/1/*")
+}
+
+// Multipath returns the BIP389 descriptor .../<0;1>/* of both chains
+func (a *Account) Multipath() string {
+	return a.descriptor("/<0;1>/*")
+}
+
+// SLIP132 returns the extended public key with its SLIP-132 version:
+// ypub and zpub for nested and native segwit, xpub otherwise
+func (a *Account) SLIP132() string {
+	switch {
+	case a.Type == SHWPKH && a.Network == Mainnet:
+		return a.XPub.SetVersion(mainnetYpub).String()
+	case a.Type == SHWPKH && a.Network == Testnet:
+		return a.XPub.SetVersion(testnetUpub).String()
+	case a.Type == WPKH && a.Network == Mainnet:
+		return a.XPub.SetVersion(mainnetZpub).String()
+	case a.Type == WPKH && a.Network == Testnet:
+		return a.XPub.SetVersion(testnetVpub).String()
+	}
+	return a.XPub.String()
+}
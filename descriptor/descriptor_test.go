@@ -0,0 +1,155 @@
+package descriptor
+
+import (
+	"testing"
+)
+
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		name string
+		desc string
+		want error
+	}{
+		{"raw", "raw(deadbeef)#89f8spxm", nil},
+		{"key origin", "pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)#ml40v0wf", nil},
+		{"missing", "raw(deadbeef)", ErrInvalidChecksum},
+		{"too short", "raw(deadbeef)#89f8spx", ErrInvalidChecksum},
+		{"too long", "raw(deadbeef)#89f8spxmx", ErrInvalidChecksum},
+		{"wrong", "raw(deadbeef)#89f8spxn", ErrInvalidChecksum},
+		{"modified", "raw(deedbeef)#89f8spxm", ErrInvalidChecksum},
+		{"invalid char", "raw(Ü)#00000000", ErrInvalidChar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyChecksum(tt.desc); err != tt.want {
+				t.Errorf("VerifyChecksum() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	got, err := AddChecksum("raw(deadbeef)")
+	if err != nil || got != "raw(deadbeef)#89f8spxm" {
+		t.Errorf("AddChecksum() = %v, %v", got, err)
+	}
+}
+
+func TestScriptType_Purpose(t *testing.T) {
+	tests := []struct {
+		typ  ScriptType
+		want uint32
+	}{
+		{PKH, 44},
+		{SHWPKH, 49},
+		{WPKH, 84},
+		{TR, 86},
+		{ScriptType(200), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.typ.Purpose(); got != tt.want {
+			t.Errorf("ScriptType(%d).Purpose() = %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     ScriptType
+		net     Network
+		path    string
+		xpub    string
+		slip132 string
+		receive string
+	}{
+		{
+			name:    "BIP44",
+			typ:     PKH,
+			path:    "m/44h/0h/0h",
+			xpub:    "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj",
+			slip132: "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj",
+		},
+		{
+			name:    "BIP49",
+			typ:     SHWPKH,
+			path:    "m/49h/0h/0h",
+			slip132: "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP",
+		},
+		{
+			name:    "BIP84",
+			typ:     WPKH,
+			path:    "m/84h/0h/0h",
+			xpub:    "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V",
+			slip132: "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+		},
+		{
+			name: "BIP86",
+			typ:  TR,
+			path: "m/86h/0h/0h",
+			xpub: "xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ",
+		},
+		{
+			name:    "BIP84 testnet",
+			typ:     WPKH,
+			net:     Testnet,
+			path:    "m/84h/1h/0h",
+			slip132: "vpub5Y6cjg78GGuNLsaPhmYsiw4gYX3HoQiRBiSwDaBXKUafCt9bNwWQiitDk5VZ5BVxYnQdwoTyXSs2JHRPAgjAvtbBrf8ZhDYe2jWAqvZVnsc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAccount(mnemonic, "", tt.typ, 0, tt.net)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := a.Path(); got != tt.path {
+				t.Errorf("Path() = %v, want %v", got, tt.path)
+			}
+			if tt.xpub != "" && a.XPub.String() != tt.xpub {
+				t.Errorf("XPub = %v, want %v", a.XPub, tt.xpub)
+			}
+			if tt.slip132 != "" && a.SLIP132() != tt.slip132 {
+				t.Errorf("SLIP132() = %v, want %v", a.SLIP132(), tt.slip132)
+			}
+			if got := a.Fingerprint; got != [4]byte{0x73, 0xc5, 0xda, 0x0a} {
+				t.Errorf("Fingerprint = %x, want 73c5da0a", got)
+			}
+			for _, desc := range []string{a.Receive(), a.Change(), a.Multipath()} {
+				if err := VerifyChecksum(desc); err != nil {
+					t.Errorf("%v: %v", desc, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDescriptor(t *testing.T) {
+	tests := []struct {
+		typ  ScriptType
+		want string
+	}{
+		{PKH, "pkh([73c5da0a/44h/0h/0h]xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj/0/*)"},
+		{WPKH, "wpkh([73c5da0a/84h/0h/0h]xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V/0/*)"},
+		{TR, "tr([73c5da0a/86h/0h/0h]xpub6BgBgsespWvERF3LHQu6CnqdvfEvtMcQjYrcRzx53QJjSxarj2afYWcLteoGVky7D3UKDP9QyrLprQ3VCECoY49yfdDEHGCtMMj92pReUsQ/0/*)"},
+	}
+	for _, tt := range tests {
+		a, err := NewAccount(mnemonic, "", tt.typ, 0, Mainnet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := AddChecksum(tt.want)
+		if got := a.Receive(); got != want {
+			t.Errorf("Receive() = %v, want %v", got, want)
+		}
+	}
+
+	a, _ := NewAccount(mnemonic, "", SHWPKH, 0, Mainnet)
+	if got := a.Change()[:len("sh(wpkh([73c5da0a/49h/0h/0h]")]; got != "sh(wpkh([73c5da0a/49h/0h/0h]" {
+		t.Errorf("Change() = %v", a.Change())
+	}
+
+	if _, err := NewAccount(mnemonic, "", TR+1, 0, Mainnet); err != ErrInvalidScriptType {
+		t.Errorf("NewAccount() error = %v, want %v", err, ErrInvalidScriptType)
+	}
+}
@@ -0,0 +1,124 @@
+package descriptor
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/adesight/bip39/bip32"
+)
+
+type coldcardAccount struct {
+	Name       string `json:"name"`
+	XFP        string `json:"xfp"`
+	Deriv      string `json:"deriv"`
+	XPub       string `json:"xpub"`
+	SLIP132Pub string `json:"_pub,omitempty"`
+	Desc       string `json:"desc"`
+}
+
+type coldcardWallet struct {
+	Chain   string           `json:"chain"`
+	XFP     string           `json:"xfp"`
+	Account uint32           `json:"account"`
+	XPub    string           `json:"xpub"`
+	BIP44   *coldcardAccount `json:"bip44"`
+	BIP49   *coldcardAccount `json:"bip49"`
+	BIP84   *coldcardAccount `json:"bip84"`
+	BIP86   *coldcardAccount `json:"bip86"`
+}
+
+// Coldcard exports the accounts of a master private key in Coldcard's
+// generic JSON format, which Sparrow, Nunchuk and others import
+func Coldcard(master *bip32.Key, index uint32, net Network) ([]byte, error) {
+	if net > Testnet {
+		return nil, ErrInvalidNetwork
+	}
+	xpub := master.Neuter()
+	chain := "BTC"
+	if net == Testnet {
+		xpub = xpub.SetVersion(bip32.TestnetPublic)
+		chain = "XTN"
+	}
+	w := coldcardWallet{
+		Chain:   chain,
+		XFP:     strings.ToUpper(hex.EncodeToString(master.Fingerprint())),
+		Account: index,
+		XPub:    xpub.String(),
+	}
+
+	for typ, dst := range map[ScriptType]**coldcardAccount{
+		PKH: &w.BIP44, SHWPKH: &w.BIP49, WPKH: &w.BIP84, TR: &w.BIP86,
+	} {
+		a, err := NewAccountFromKey(master, typ, index, net)
+		if err != nil {
+			return nil, err
+		}
+		ca := &coldcardAccount{
+			Name:  scriptTypes[typ].name,
+			XFP:   w.XFP,
+			Deriv: strings.ReplaceAll(a.Path(), "h", "'"),
+			XPub:  a.XPub.String(),
+			Desc:  a.Multipath(),
+		}
+		if pub := a.SLIP132(); pub != ca.XPub {
+			ca.SLIP132Pub = pub
+		}
+		*dst = ca
+	}
+	return json.MarshalIndent(w, "", "  ")
+}
+
+type specterDevice struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+type specterWallet struct {
+	Label       string          `json:"label"`
+	BlockHeight uint32          `json:"blockheight"`
+	Descriptor  string          `json:"descriptor"`
+	Devices     []specterDevice `json:"devices"`
+}
+
+// Specter exports the account as a Specter Desktop wallet file
+func (a *Account) Specter(label string) ([]byte, error) {
+	return json.MarshalIndent(specterWallet{
+		Label:      label,
+		Descriptor: a.Receive(),
+		Devices:    []specterDevice{{Type: "other", Label: label}},
+	}, "", "  ")
+}
+
+type electrumKeystore struct {
+	XPub            string `json:"xpub"`
+	Derivation      string `json:"derivation"`
+	RootFingerprint string `json:"root_fingerprint"`
+	Label           string `json:"label"`
+	Type            string `json:"type"`
+}
+
+type electrumWallet struct {
+	Keystore   electrumKeystore `json:"keystore"`
+	WalletType string           `json:"wallet_type"`
+}
+
+// BlueWallet exports the account as the Electrum style watch-only wallet
+// file imported by BlueWallet; taproot has no SLIP-132 version and is
+// exported as its descriptor instead
+func (a *Account) BlueWallet(label string) ([]byte, error) {
+	if a.Type == TR {
+		return []byte(a.Multipath()), nil
+	}
+	return json.MarshalIndent(electrumWallet{
+		Keystore: electrumKeystore{
+			XPub:            a.SLIP132(),
+			Derivation:      strings.ReplaceAll(a.Path(), "h", "'"),
+			RootFingerprint: fmt.Sprintf("%x", a.Fingerprint),
+			Label:           label,
+			Type:            "bip32",
+		},
+		WalletType: "standard",
+	}, "", "  ")
+}
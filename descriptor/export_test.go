@@ -0,0 +1,85 @@
+package descriptor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39/bip32"
+)
+
+func TestColdcard(t *testing.T) {
+	master, err := bip32.NewMasterKeyFromMnemonic(mnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Coldcard(master, 0, Mainnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w coldcardWallet
+	if err := json.Unmarshal(out, &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.XFP != "73C5DA0A" || w.Chain != "BTC" {
+		t.Errorf("Coldcard() xfp = %v, chain = %v", w.XFP, w.Chain)
+	}
+	if w.BIP84.Deriv != "m/84'/0'/0'" || w.BIP84.Name != "p2wpkh" {
+		t.Errorf("Coldcard() bip84 = %+v", w.BIP84)
+	}
+	if w.BIP84.SLIP132Pub != "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs" {
+		t.Errorf("Coldcard() bip84 _pub = %v", w.BIP84.SLIP132Pub)
+	}
+	if w.BIP44.SLIP132Pub != "" || w.BIP86.SLIP132Pub != "" {
+		t.Errorf("Coldcard() unexpected _pub for bip44/bip86")
+	}
+	if !strings.HasPrefix(w.BIP86.Desc, "tr([73c5da0a/86h/0h/0h]xpub") || !strings.Contains(w.BIP86.Desc, "/<0;1>/*)#") {
+		t.Errorf("Coldcard() bip86 desc = %v", w.BIP86.Desc)
+	}
+
+	if out, _ = Coldcard(master, 0, Testnet); !strings.Contains(string(out), `"chain": "XTN"`) {
+		t.Errorf("Coldcard() testnet = %s", out)
+	}
+}
+
+func TestSpecter(t *testing.T) {
+	a, _ := NewAccount(mnemonic, "", WPKH, 0, Mainnet)
+	out, err := a.Specter("hot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w specterWallet
+	if err := json.Unmarshal(out, &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.Label != "hot" || w.Descriptor != a.Receive() || len(w.Devices) != 1 {
+		t.Errorf("Specter() = %s", out)
+	}
+}
+
+func TestBlueWallet(t *testing.T) {
+	a, _ := NewAccount(mnemonic, "", WPKH, 0, Mainnet)
+	out, err := a.BlueWallet("hot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w electrumWallet
+	if err := json.Unmarshal(out, &w); err != nil {
+		t.Fatal(err)
+	}
+	want := electrumKeystore{
+		XPub:            "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+		Derivation:      "m/84'/0'/0'",
+		RootFingerprint: "73c5da0a",
+		Label:           "hot",
+		Type:            "bip32",
+	}
+	if w.Keystore != want || w.WalletType != "standard" {
+		t.Errorf("BlueWallet() = %s", out)
+	}
+
+	tr, _ := NewAccount(mnemonic, "", TR, 0, Mainnet)
+	if out, _ := tr.BlueWallet("hot"); string(out) != tr.Multipath() {
+		t.Errorf("BlueWallet() taproot = %s", out)
+	}
+}
@@ -0,0 +1,124 @@
+package bip39
+
+import (
+	"crypto/sha512"
+	"errors"
+	"io"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Error list
+var (
+	ErrWeakSecret    = errors.New("Invalid secret, should be at least 32 random bytes")
+	ErrTextSecret    = errors.New("Invalid secret, human passphrases need DeriveMnemonicFromPassphrase")
+	ErrEmptyDomain   = errors.New("Invalid empty domain label")
+	ErrWeakHardening = errors.New("Invalid passphrase hardening, Argon2id cost below the minimum")
+	ErrEmptyPassword = errors.New("Invalid empty passphrase")
+)
+
+// MinSecretLen is the shortest secret accepted by DeriveMnemonic
+const MinSecretLen = 32
+
+// HKDF salts keeping derived mnemonics apart from any other use of a secret
+const (
+	deterministicSalt = "bip39 deterministic mnemonic v1"
+	passphraseSalt    = "bip39 passphrase mnemonic v1"
+)
+
+// DeriveMnemonic derives a mnemonic of wordsLen words from a high entropy
+// master secret with HKDF-SHA512, the domain label and the length being the
+// HKDF info. Different domains or lengths give unrelated mnemonics from the
+// same secret.
+//
+// The secret must come from a CSPRNG or a KDF: short secrets and secrets
+// that are printable text, like passphrases or hex strings, are rejected.
+func DeriveMnemonic(secret []byte, domain string, wordsLen int, lang Language) (string, error) {
+	if len(secret) < MinSecretLen {
+		return "", ErrWeakSecret
+	}
+	if isText(secret) {
+		return "", ErrTextSecret
+	}
+	return deriveMnemonic(secret, deterministicSalt, domain, wordsLen, lang)
+}
+
+func deriveMnemonic(secret []byte, salt, domain string, wordsLen int, lang Language) (string, error) {
+	if domain == "" {
+		return "", ErrEmptyDomain
+	}
+	if !validWordsLen(wordsLen) {
+		return "", ErrWordLen
+	}
+	entropy := make([]byte, wordsLen+wordsLen/3)
+	info := domain + "\x00" + strconv.Itoa(wordsLen)
+	r := hkdf.New(sha512.New, secret, []byte(salt), []byte(info))
+	if _, err := io.ReadFull(r, entropy); err != nil {
+		return "", err
+	}
+	return NewMnemonicByEntropy(entropy, lang)
+}
+
+func validWordsLen(wordsLen int) bool {
+	return wordsLen >= 12 && wordsLen <= 24 && wordsLen%3 == 0
+}
+
+// isText reports whether secret decodes as printable UTF-8, which 32 random
+// bytes do with a probability below 10^-13
+func isText(secret []byte) bool {
+	if !utf8.Valid(secret) {
+		return false
+	}
+	for _, r := range string(secret) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// PassphraseHardening is the Argon2id cost of DeriveMnemonicFromPassphrase
+type PassphraseHardening struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+}
+
+// Lowest accepted passphrase hardening: 1 GiB and 4 passes, so every guess
+// of an attacker costs seconds of a large machine
+const (
+	minHardeningTime   = 4
+	minHardeningMemory = 1 << 20 // KiB
+)
+
+// DeriveMnemonicFromPassphrase derives a mnemonic from a human passphrase,
+// i.e. a brain wallet. Human passphrases are guessable, so the passphrase is
+// first stretched with Argon2id, salted with the domain label, at a cost no
+// lower than 4 passes over 1 GiB.
+func DeriveMnemonicFromPassphrase(passphrase string, domain string, wordsLen int, lang Language, h PassphraseHardening) (string, error) {
+	if passphrase == "" {
+		return "", ErrEmptyPassword
+	}
+	if domain == "" {
+		return "", ErrEmptyDomain
+	}
+	// checked before the costly hardening
+	if !validWordsLen(wordsLen) {
+		return "", ErrWordLen
+	}
+	if h.Time < minHardeningTime || h.Memory < minHardeningMemory || h.Threads < 1 {
+		return "", ErrWeakHardening
+	}
+	return passphraseMnemonic(passphrase, domain, wordsLen, lang, h)
+}
+
+func passphraseMnemonic(passphrase string, domain string, wordsLen int, lang Language, h PassphraseHardening) (string, error) {
+	salt := sha512.Sum512([]byte(passphraseSalt + "\x00" + domain))
+	secret := argon2.IDKey([]byte(norm.NFKD.String(passphrase)), salt[:], h.Time, h.Memory, h.Threads, 64)
+	return deriveMnemonic(secret, passphraseSalt, domain, wordsLen, lang)
+}
@@ -0,0 +1,25 @@
+package bip39
+
+import "testing"
+
+func TestPassphraseMnemonic(t *testing.T) {
+	// below the accepted minimum to keep the test fast, the minimum needs 1 GiB
+	h := PassphraseHardening{Time: 2, Memory: 1024, Threads: 1}
+
+	a, err := passphraseMnemonic("correct horse battery staple", "ci", 12, English, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsMnemonicValid(a, English) {
+		t.Errorf("passphraseMnemonic() = %v, invalid", a)
+	}
+	if b, _ := passphraseMnemonic("correct horse battery staple", "ci", 12, English, h); a != b {
+		t.Errorf("passphraseMnemonic() not deterministic: %v, %v", a, b)
+	}
+	if b, _ := passphraseMnemonic("correct horse battery staple", "prod", 12, English, h); a == b {
+		t.Errorf("passphraseMnemonic() ignores the domain")
+	}
+	if b, _ := passphraseMnemonic("correct horse battery stapler", "ci", 12, English, h); a == b {
+		t.Errorf("passphraseMnemonic() ignores the passphrase")
+	}
+}
@@ -0,0 +1,89 @@
+package bip39_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestDeriveMnemonic(t *testing.T) {
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+	tests := []struct {
+		name     string
+		domain   string
+		wordsLen int
+		entropy  string
+	}{
+		{"12 words", "ci/alice", 12, "5d5eba6e7c39a97322a7793cb5eca635"},
+		{"other domain", "ci/bob", 12, "98d9f3147a341a204891e95484c7acb4"},
+		{"24 words", "ci/alice", 24, "aeebc539b07d763e839768daefd3e44d7ebc1d520c160cb2589dd22d8736313c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bip39.DeriveMnemonic(secret, tt.domain, tt.wordsLen, bip39.English)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := bip39.NewMnemonicByEntropy(mustDecodeHex(tt.entropy), bip39.English)
+			if got != want {
+				t.Errorf("DeriveMnemonic() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDeriveMnemonicInvalid(t *testing.T) {
+	secret := make([]byte, 32)
+	secret[0] = 0xff
+	tests := []struct {
+		name     string
+		secret   []byte
+		domain   string
+		wordsLen int
+		want     error
+	}{
+		{"short secret", secret[:16], "ci", 12, bip39.ErrWeakSecret},
+		{"passphrase", []byte("correct horse battery staple and more words"), "ci", 12, bip39.ErrTextSecret},
+		{"hex string", []byte(strings.Repeat("0f", 32)), "ci", 12, bip39.ErrTextSecret},
+		{"empty domain", secret, "", 12, bip39.ErrEmptyDomain},
+		{"word length", secret, "ci", 13, bip39.ErrWordLen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := bip39.DeriveMnemonic(tt.secret, tt.domain, tt.wordsLen, bip39.English); err != tt.want {
+				t.Errorf("DeriveMnemonic() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeriveMnemonicFromPassphraseInvalid(t *testing.T) {
+	h := bip39.PassphraseHardening{Time: 4, Memory: 1 << 20, Threads: 4}
+	tests := []struct {
+		name       string
+		passphrase string
+		domain     string
+		wordsLen   int
+		hardening  bip39.PassphraseHardening
+		want       error
+	}{
+		{"empty passphrase", "", "ci", 12, h, bip39.ErrEmptyPassword},
+		{"empty domain", "correct horse", "", 12, h, bip39.ErrEmptyDomain},
+		// rejected before 1 GiB of Argon2id, or the test would take seconds
+		{"word length", "correct horse", "ci", 13, h, bip39.ErrWordLen},
+		{"few passes", "correct horse", "ci", 12, bip39.PassphraseHardening{Time: 3, Memory: 1 << 20, Threads: 4}, bip39.ErrWeakHardening},
+		{"little memory", "correct horse", "ci", 12, bip39.PassphraseHardening{Time: 4, Memory: 1024, Threads: 4}, bip39.ErrWeakHardening},
+		{"no thread", "correct horse", "ci", 12, bip39.PassphraseHardening{Time: 4, Memory: 1 << 20}, bip39.ErrWeakHardening},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := bip39.DeriveMnemonicFromPassphrase(tt.passphrase, tt.domain, tt.wordsLen, bip39.English, tt.hardening); err != tt.want {
+				t.Errorf("DeriveMnemonicFromPassphrase() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// Package envelope seals mnemonics for storage at rest.
+//
+// An envelope is a versioned binary record: a header naming the KDF, its
+// parameters, the AEAD cipher, the mnemonic language and word count, then
+// the salt, nonce and the sealed entropy. The whole header is the associated
+// data of the AEAD, so none of it can be altered without failing Open, and
+// Inspect reads it without the password. Only the entropy is encrypted: no
+// word of the mnemonic is ever stored in plaintext.
+//
+//	magic "B39E" | version | kdf | kdf params (9) | cipher | words |
+//	len(lang) | lang | salt (16) | nonce | ciphertext
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+
+	"github.com/adesight/bip39"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Version is the envelope format version written and read
+const Version = 1
+
+// ArmorType is the PEM block type of armored envelopes
+const ArmorType = "BIP39 MNEMONIC ENVELOPE"
+
+const (
+	saltLen   = 16
+	keyLen    = 32
+	paramsLen = 9
+)
+
+var magic = []byte("B39E")
+
+// KDF derives the encryption key from the password
+type KDF uint8
+
+// KDF list
+const (
+	Argon2id KDF = iota + 1
+	Scrypt
+)
+
+// Cipher is the AEAD sealing the entropy
+type Cipher uint8
+
+// Cipher list
+const (
+	XChaCha20Poly1305 Cipher = iota + 1
+	AESGCM
+)
+
+// Error list
+var (
+	ErrInvalidEnvelope = errors.New("Invalid envelope")
+	ErrVersion         = errors.New("Unsupported envelope version")
+	ErrInvalidParams   = errors.New("Invalid envelope parameters")
+	ErrInvalidPassword = errors.New("Invalid password or corrupted envelope")
+	ErrArmor           = errors.New("Invalid envelope armor")
+)
+
+// Params selects the KDF, its cost and the cipher of new envelopes
+type Params struct {
+	KDF    KDF
+	Cipher Cipher
+	// Argon2id passes, memory in KiB and lanes
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	// scrypt cost 2^LogN, block size and parallelism
+	LogN uint8
+	R    uint32
+	P    uint32
+}
+
+// Default parameters, following the RFC 9106 second recommended option for
+// Argon2id and the interactive login setting of scrypt
+var (
+	DefaultParams = Params{KDF: Argon2id, Cipher: XChaCha20Poly1305, Time: 3, Memory: 64 * 1024, Threads: 4}
+	ScryptParams  = Params{KDF: Scrypt, Cipher: AESGCM, LogN: 15, R: 8, P: 1}
+)
+
+// Upper bounds of parameters accepted by Open, so a crafted envelope cannot
+// exhaust memory or time
+const (
+	maxTime    = 16
+	maxMemory  = 1024 * 1024 // KiB, 1 GiB
+	maxThreads = 16
+	maxLogN    = 22
+	maxR       = 32
+	maxP       = 16
+	// scrypt uses 128 * R * 2^LogN bytes
+	maxScryptMemory = 1 << 30
+)
+
+// randReader is the source of salts and nonces
+var randReader io.Reader = rand.Reader
+
+func (p Params) valid() bool {
+	switch p.KDF {
+	case Argon2id:
+		if p.Time < 1 || p.Time > maxTime || p.Threads < 1 || p.Threads > maxThreads ||
+			p.Memory < 8*uint32(p.Threads) || p.Memory > maxMemory {
+			return false
+		}
+	case Scrypt:
+		if p.LogN < 1 || p.LogN > maxLogN || p.R < 1 || p.R > maxR || p.P < 1 || p.P > maxP ||
+			128*uint64(p.R)<<p.LogN > maxScryptMemory {
+			return false
+		}
+	default:
+		return false
+	}
+	return p.Cipher == XChaCha20Poly1305 || p.Cipher == AESGCM
+}
+
+func (p Params) key(password string, salt []byte) ([]byte, error) {
+	if p.KDF == Argon2id {
+		return argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, keyLen), nil
+	}
+	return scrypt.Key([]byte(password), salt, 1<<p.LogN, int(p.R), int(p.P), keyLen)
+}
+
+func (p Params) aead(key []byte) (cipher.AEAD, error) {
+	if p.Cipher == XChaCha20Poly1305 {
+		return chacha20poly1305.NewX(key)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (p Params) nonceSize() int {
+	if p.Cipher == XChaCha20Poly1305 {
+		return chacha20poly1305.NonceSizeX
+	}
+	return 12
+}
+
+// Header is the plaintext part of an envelope
+type Header struct {
+	Params   Params
+	Language bip39.Language
+	Words    int
+}
+
+// Seal encrypts a mnemonic with a password
+func Seal(mnemonic string, lang bip39.Language, password string, params Params) ([]byte, error) {
+	if !params.valid() {
+		return nil, ErrInvalidParams
+	}
+	entropy, err := bip39.MnemonicToEntropy(mnemonic, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	h := Header{Params: params, Language: lang, Words: len(entropy) * 3 / 4}
+	ad := h.marshal()
+	salt := make([]byte, saltLen)
+	nonce := make([]byte, params.nonceSize())
+	if _, err := io.ReadFull(randReader, salt); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(randReader, nonce); err != nil {
+		return nil, err
+	}
+	ad = append(append(ad, salt...), nonce...)
+
+	key, err := params.key(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := params.aead(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(ad, nonce, entropy, ad), nil
+}
+
+// Open decrypts an envelope and returns the mnemonic and its language
+func Open(data []byte, password string) (string, bip39.Language, error) {
+	h, rest, err := parseHeader(data)
+	if err != nil {
+		return "", 0, err
+	}
+	nonceSize := h.Params.nonceSize()
+	if len(rest) < saltLen+nonceSize {
+		return "", 0, ErrInvalidEnvelope
+	}
+	salt, nonce := rest[:saltLen], rest[saltLen:saltLen+nonceSize]
+	adLen := len(data) - len(rest) + saltLen + nonceSize
+
+	key, err := h.Params.key(password, salt)
+	if err != nil {
+		return "", 0, err
+	}
+	aead, err := h.Params.aead(key)
+	if err != nil {
+		return "", 0, err
+	}
+	entropy, err := aead.Open(nil, nonce, data[adLen:], data[:adLen])
+	if err != nil {
+		return "", 0, ErrInvalidPassword
+	}
+	if len(entropy)*3/4 != h.Words {
+		return "", 0, ErrInvalidEnvelope
+	}
+	mnemonic, err := bip39.NewMnemonicByEntropy(entropy, h.Language)
+	if err != nil {
+		return "", 0, err
+	}
+	return mnemonic, h.Language, nil
+}
+
+// Rotate opens an envelope and seals its mnemonic again with a new password
+// and new parameters, e.g. when the defaults are raised
+func Rotate(data []byte, password, newPassword string, params Params) ([]byte, error) {
+	mnemonic, lang, err := Open(data, password)
+	if err != nil {
+		return nil, err
+	}
+	return Seal(mnemonic, lang, newPassword, params)
+}
+
+// Inspect reads the header of an envelope without the password, e.g. to find
+// envelopes sealed with parameters due for rotation
+func Inspect(data []byte) (*Header, error) {
+	h, _, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Header) marshal() []byte {
+	p := h.Params
+	buf := append([]byte(nil), magic...)
+	buf = append(buf, Version, byte(p.KDF))
+	params := make([]byte, paramsLen)
+	if p.KDF == Argon2id {
+		binary.BigEndian.PutUint32(params[0:], p.Time)
+		binary.BigEndian.PutUint32(params[4:], p.Memory)
+		params[8] = p.Threads
+	} else {
+		binary.BigEndian.PutUint32(params[0:], p.R)
+		binary.BigEndian.PutUint32(params[4:], p.P)
+		params[8] = p.LogN
+	}
+	buf = append(buf, params...)
+	code := h.Language.Code()
+	buf = append(buf, byte(p.Cipher), byte(h.Words), byte(len(code)))
+	return append(buf, code...)
+}
+
+func parseHeader(data []byte) (*Header, []byte, error) {
+	fixed := len(magic) + 2 + paramsLen + 3
+	if len(data) < fixed || !bytes.Equal(data[:len(magic)], magic) {
+		return nil, nil, ErrInvalidEnvelope
+	}
+	if data[len(magic)] != Version {
+		return nil, nil, ErrVersion
+	}
+
+	h := &Header{}
+	p := &h.Params
+	p.KDF = KDF(data[len(magic)+1])
+	params := data[len(magic)+2 : len(magic)+2+paramsLen]
+	switch p.KDF {
+	case Argon2id:
+		p.Time = binary.BigEndian.Uint32(params[0:])
+		p.Memory = binary.BigEndian.Uint32(params[4:])
+		p.Threads = params[8]
+	case Scrypt:
+		p.R = binary.BigEndian.Uint32(params[0:])
+		p.P = binary.BigEndian.Uint32(params[4:])
+		p.LogN = params[8]
+	}
+	p.Cipher = Cipher(data[fixed-3])
+	if !p.valid() {
+		return nil, nil, ErrInvalidParams
+	}
+
+	h.Words = int(data[fixed-2])
+	if h.Words < 12 || h.Words > 24 || h.Words%3 != 0 {
+		return nil, nil, ErrInvalidEnvelope
+	}
+	codeLen := int(data[fixed-1])
+	if len(data) < fixed+codeLen {
+		return nil, nil, ErrInvalidEnvelope
+	}
+	lang, err := bip39.ParseLanguage(string(data[fixed : fixed+codeLen]))
+	if err != nil {
+		return nil, nil, err
+	}
+	h.Language = lang
+	return h, data[fixed+codeLen:], nil
+}
+
+// Armor encodes an envelope as a PEM text block
+func Armor(data []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: ArmorType, Bytes: data}))
+}
+
+// Dearmor decodes the first envelope PEM block of text
+func Dearmor(text string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil || block.Type != ArmorType {
+		return nil, ErrArmor
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,167 @@
+package envelope
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+const mnemonic = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+// cheap parameters, the defaults take too long for tests
+var (
+	testArgon2 = Params{KDF: Argon2id, Cipher: XChaCha20Poly1305, Time: 1, Memory: 64, Threads: 1}
+	testScrypt = Params{KDF: Scrypt, Cipher: AESGCM, LogN: 10, R: 8, P: 1}
+)
+
+func TestSealOpen(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+	}{
+		{"argon2id xchacha20poly1305", testArgon2},
+		{"argon2id aes-gcm", Params{KDF: Argon2id, Cipher: AESGCM, Time: 1, Memory: 64, Threads: 1}},
+		{"scrypt xchacha20poly1305", Params{KDF: Scrypt, Cipher: XChaCha20Poly1305, LogN: 10, R: 8, P: 1}},
+		{"scrypt aes-gcm", testScrypt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Seal(mnemonic, bip39.English, "secret", tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range strings.Fields(mnemonic) {
+				if bytes.Contains(data, []byte(w)) {
+					t.Errorf("Seal() leaks word %v", w)
+				}
+			}
+			got, lang, err := Open(data, "secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != mnemonic || lang != bip39.English {
+				t.Errorf("Open() = %v, %v, want %v", got, lang, mnemonic)
+			}
+			if _, _, err := Open(data, "wrong"); err != ErrInvalidPassword {
+				t.Errorf("Open() error = %v, want %v", err, ErrInvalidPassword)
+			}
+
+			h, err := Inspect(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *h != (Header{Params: tt.params, Language: bip39.English, Words: 12}) {
+				t.Errorf("Inspect() = %+v", h)
+			}
+		})
+	}
+}
+
+func TestAssociatedData(t *testing.T) {
+	data, err := Seal(mnemonic, bip39.English, "secret", testArgon2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "en" to "es" and 12 to 15 words keep the header well formed
+	for _, tamper := range []struct{ old, new string }{
+		{"\x02en", "\x02es"},
+		{"\x0c\x02en", "\x0f\x02en"},
+	} {
+		changed := bytes.Replace(data, []byte(tamper.old), []byte(tamper.new), 1)
+		if bytes.Equal(changed, data) {
+			t.Fatalf("header %q not found", tamper.old)
+		}
+		if _, _, err := Open(changed, "secret"); err != ErrInvalidPassword {
+			t.Errorf("Open() error = %v, want %v", err, ErrInvalidPassword)
+		}
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	data, _ := Seal(mnemonic, bip39.English, "secret", testScrypt)
+	argon2Data, _ := Seal(mnemonic, bip39.English, "secret", testArgon2)
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrInvalidEnvelope},
+		{"magic", append([]byte("B39X"), data[4:]...), ErrInvalidEnvelope},
+		{"version", append([]byte("B39E\x02"), data[5:]...), ErrVersion},
+		{"kdf", append([]byte("B39E\x01\x09"), data[6:]...), ErrInvalidParams},
+		{"truncated", data[:30], ErrInvalidEnvelope},
+		{"scrypt memory", withParams(data, "\x00\x10\x00\x00\x00\x00\x00\x01\x16"), ErrInvalidParams},
+		{"scrypt block size", withParams(data, "\x00\x00\x01\x00\x00\x00\x00\x01\x0a"), ErrInvalidParams},
+		{"scrypt parallelism", withParams(data, "\x00\x00\x00\x08\x00\x01\x00\x00\x0a"), ErrInvalidParams},
+		{"argon2 memory", withParams(argon2Data, "\x00\x00\x00\x01\x00\x10\x00\x01\x01"), ErrInvalidParams},
+		{"argon2 time", withParams(argon2Data, "\x00\x00\x00\x11\x00\x00\x04\x00\x01"), ErrInvalidParams},
+		{"argon2 threads", withParams(argon2Data, "\x00\x00\x00\x01\x00\x00\x04\x00\x11"), ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Open(tt.data, "secret"); err != tt.want {
+				t.Errorf("Open() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Seal(mnemonic, bip39.English, "secret", Params{KDF: Argon2id}); err != ErrInvalidParams {
+		t.Errorf("Seal() error = %v, want %v", err, ErrInvalidParams)
+	}
+	if _, err := Seal("legal winner", bip39.English, "secret", testArgon2); err != bip39.ErrInvalidMnemonic {
+		t.Errorf("Seal() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+	}
+}
+
+// withParams replaces the 9 bytes of KDF parameters of an envelope
+func withParams(data []byte, params string) []byte {
+	out := append([]byte(nil), data...)
+	copy(out[6:6+paramsLen], params)
+	return out
+}
+
+func TestRotate(t *testing.T) {
+	japanese, _ := bip39.NewMnemonic(24, bip39.Japanese)
+	data, err := Seal(japanese, bip39.Japanese, "old", testScrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := Rotate(data, "old", "new", testArgon2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, _ := Inspect(rotated)
+	if h.Params != testArgon2 || h.Language != bip39.Japanese || h.Words != 24 {
+		t.Errorf("Inspect() = %+v", h)
+	}
+	got, _, err := Open(rotated, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != japanese {
+		t.Errorf("Open() = %v, want %v", got, japanese)
+	}
+	if _, err := Rotate(data, "new", "newer", testArgon2); err != ErrInvalidPassword {
+		t.Errorf("Rotate() error = %v, want %v", err, ErrInvalidPassword)
+	}
+}
+
+func TestArmor(t *testing.T) {
+	data, _ := Seal(mnemonic, bip39.English, "secret", testArgon2)
+	text := Armor(data)
+	if !strings.HasPrefix(text, "-----BEGIN BIP39 MNEMONIC ENVELOPE-----\n") {
+		t.Errorf("Armor() = %v", text)
+	}
+	got, err := Dearmor("stored at rest\n" + text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Dearmor() = %x, want %x", got, data)
+	}
+	if _, err := Dearmor(strings.Replace(text, "BIP39 MNEMONIC", "PRIVATE KEY", -1)); err != ErrArmor {
+		t.Errorf("Dearmor() error = %v, want %v", err, ErrArmor)
+	}
+}
@@ -0,0 +1,23 @@
+module github.com/adesight/bip39
+
+go 1.25.0
+
+require (
+	filippo.io/edwards25519 v1.1.0
+	github.com/ChainSafe/go-schnorrkel v1.0.0
+	github.com/Yawning/aez v0.0.0-20211027044916-e49e68abd344
+	github.com/boombuler/barcode v1.1.0
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.3.0
+	golang.org/x/crypto v0.54.0
+	golang.org/x/term v0.45.0
+	golang.org/x/text v0.40.0
+)
+
+require (
+	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d // indirect
+	github.com/gtank/merlin v0.1.1-0.20191105220539-8318aed1a79f // indirect
+	github.com/gtank/ristretto255 v0.1.2 // indirect
+	github.com/mimoo/StrobeGo v0.0.0-20181016162300-f8f6d4d2b643 // indirect
+	gitlab.com/yawning/bsaes.git v0.0.0-20190805113838-0a714cd429ec // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
@@ -0,0 +1,169 @@
+package bip39
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Error list
+var (
+	ErrInvalidIndex = errors.New("Invalid word index")
+	ErrIndexFormat  = errors.New("Invalid word index format")
+)
+
+// IndexFormat is a numeric representation of word indices, as recorded on
+// metal backups and by some hardware wallets
+type IndexFormat uint8
+
+// IndexFormat list
+const (
+	// IndexOneBased is decimal 1–2048, the numbering of printed word lists
+	IndexOneBased IndexFormat = iota
+	// IndexZeroBased is decimal 0–2047
+	IndexZeroBased
+	// IndexBinary is 11 bits per word, the punch pattern of metal plates
+	IndexBinary
+	// IndexOctal is 4 octal digits 0000–3777
+	IndexOctal
+	// IndexHex is 3 hex digits 000–7ff
+	IndexHex
+)
+
+var indexFormatNames = [...]string{
+	IndexOneBased:  "decimal",
+	IndexZeroBased: "decimal0",
+	IndexBinary:    "binary",
+	IndexOctal:     "octal",
+	IndexHex:       "hex",
+}
+
+func (f IndexFormat) String() string {
+	if int(f) < len(indexFormatNames) {
+		return indexFormatNames[f]
+	}
+	return fmt.Sprintf("IndexFormat(%d)", f)
+}
+
+// MnemonicToIndices returns the zero based word indices of a valid mnemonic
+func MnemonicToIndices(mnemonic string, lang Language) ([]int, error) {
+	if _, err := MnemonicToEntropy(mnemonic, lang); err != nil {
+		return nil, err
+	}
+	wordMapping := indexMapping(lang)
+	words := strings.Split(norm.NFKD.String(mnemonic), "\x20")
+	indices := make([]int, len(words))
+	for i, w := range words {
+		indices[i] = wordMapping[w]
+	}
+	return indices, nil
+}
+
+// IndicesToMnemonic returns the mnemonic of zero based word indices,
+// rejecting indices out of range and a wrong checksum
+func IndicesToMnemonic(indices []int, lang Language) (string, error) {
+	wordList := lang.List()
+	words := make([]string, len(indices))
+	for i, idx := range indices {
+		if idx < 0 || idx >= len(wordList) {
+			return "", ErrInvalidIndex
+		}
+		words[i] = wordList[idx]
+	}
+	mnemonic := strings.Join(words, lang.Separator())
+	if !IsMnemonicValid(mnemonic, lang) {
+		return "", ErrInvalidMnemonic
+	}
+	return mnemonic, nil
+}
+
+// EncodeIndices returns the word indices of a mnemonic in format, separated
+// by spaces
+func EncodeIndices(mnemonic string, lang Language, format IndexFormat) (string, error) {
+	if int(format) >= len(indexFormatNames) {
+		return "", ErrIndexFormat
+	}
+	indices, err := MnemonicToIndices(mnemonic, lang)
+	if err != nil {
+		return "", err
+	}
+	out := make([]string, len(indices))
+	for i, idx := range indices {
+		switch format {
+		case IndexOneBased:
+			out[i] = strconv.Itoa(idx + 1)
+		case IndexZeroBased:
+			out[i] = strconv.Itoa(idx)
+		case IndexBinary:
+			out[i] = fmt.Sprintf("%011b", idx)
+		case IndexOctal:
+			out[i] = fmt.Sprintf("%04o", idx)
+		case IndexHex:
+			out[i] = fmt.Sprintf("%03x", idx)
+		}
+	}
+	return strings.Join(out, "\x20"), nil
+}
+
+// DecodeIndices returns the mnemonic of word indices in format, separated
+// by spaces, commas or new lines. Binary indices may also be given as one
+// string of 11 bits per word.
+func DecodeIndices(s string, lang Language, format IndexFormat) (string, error) {
+	if int(format) >= len(indexFormatNames) {
+		return "", ErrIndexFormat
+	}
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+	if format == IndexBinary && len(tokens) == 1 && len(tokens[0])%11 == 0 {
+		bits := tokens[0]
+		tokens = tokens[:0]
+		for i := 0; i < len(bits); i += 11 {
+			tokens = append(tokens, bits[i:i+11])
+		}
+	}
+
+	indices := make([]int, len(tokens))
+	for i, tok := range tokens {
+		idx, err := parseIndex(tok, format)
+		if err != nil {
+			return "", err
+		}
+		indices[i] = idx
+	}
+	return IndicesToMnemonic(indices, lang)
+}
+
+func parseIndex(tok string, format IndexFormat) (int, error) {
+	var (
+		v   uint64
+		err error
+	)
+	switch format {
+	case IndexOneBased:
+		v, err = strconv.ParseUint(tok, 10, 16)
+		if v == 0 {
+			return 0, ErrInvalidIndex
+		}
+		v--
+	case IndexZeroBased:
+		v, err = strconv.ParseUint(tok, 10, 16)
+	case IndexBinary:
+		if len(tok) != 11 {
+			return 0, ErrInvalidIndex
+		}
+		v, err = strconv.ParseUint(tok, 2, 16)
+	case IndexOctal:
+		v, err = strconv.ParseUint(tok, 8, 16)
+	case IndexHex:
+		v, err = strconv.ParseUint(tok, 16, 16)
+	}
+	if err != nil || v >= 2048 {
+		return 0, ErrInvalidIndex
+	}
+	return int(v), nil
+}
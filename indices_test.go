@@ -0,0 +1,96 @@
+package bip39_test
+
+import (
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestEncodeIndices(t *testing.T) {
+	const mnemonic = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	tests := []struct {
+		format bip39.IndexFormat
+		want   string
+	}{
+		{bip39.IndexOneBased, "1020 2016 1791 2040 1984 1534 2032 1920 1020 2016 1791 2041"},
+		{bip39.IndexZeroBased, "1019 2015 1790 2039 1983 1533 2031 1919 1019 2015 1790 2040"},
+		{bip39.IndexBinary, "01111111011 11111011111 11011111110 11111110111 11110111111 10111111101 11111101111 11101111111 01111111011 11111011111 11011111110 11111111000"},
+		{bip39.IndexOctal, "1773 3737 3376 3767 3677 2775 3757 3577 1773 3737 3376 3770"},
+		{bip39.IndexHex, "3fb 7df 6fe 7f7 7bf 5fd 7ef 77f 3fb 7df 6fe 7f8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			got, err := bip39.EncodeIndices(mnemonic, bip39.English, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("EncodeIndices() = %v, want %v", got, tt.want)
+			}
+			back, err := bip39.DecodeIndices(got, bip39.English, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back != mnemonic {
+				t.Errorf("DecodeIndices() = %v, want %v", back, mnemonic)
+			}
+		})
+	}
+}
+
+func TestDecodeIndices(t *testing.T) {
+	const about = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	japanese, _ := bip39.NewMnemonicByEntropy(make([]byte, 16), bip39.Japanese)
+	tests := []struct {
+		name   string
+		s      string
+		lang   bip39.Language
+		format bip39.IndexFormat
+		want   string
+		err    error
+	}{
+		{"comma separated", "1,1,1,1,1,1,1,1,1,1,1,4", bip39.English, bip39.IndexOneBased, about, nil},
+		{"new lines", "0\n0\n0\n0\n0\n0\n0\n0\n0\n0\n0\n3\n", bip39.English, bip39.IndexZeroBased, about, nil},
+		{"bit string", "000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000011", bip39.English, bip39.IndexBinary, about, nil},
+		{"japanese", "1 1 1 1 1 1 1 1 1 1 1 4", bip39.Japanese, bip39.IndexOneBased, japanese, nil},
+		{"zero one based", "0 1 1 1 1 1 1 1 1 1 1 4", bip39.English, bip39.IndexOneBased, "", bip39.ErrInvalidIndex},
+		{"out of range", "2049 1 1 1 1 1 1 1 1 1 1 4", bip39.English, bip39.IndexOneBased, "", bip39.ErrInvalidIndex},
+		{"octal digit", "0008 0 0 0 0 0 0 0 0 0 0 3", bip39.English, bip39.IndexOctal, "", bip39.ErrInvalidIndex},
+		{"hex range", "800 0 0 0 0 0 0 0 0 0 0 3", bip39.English, bip39.IndexHex, "", bip39.ErrInvalidIndex},
+		{"binary width", "0 0 0 0 0 0 0 0 0 0 0 11", bip39.English, bip39.IndexBinary, "", bip39.ErrInvalidIndex},
+		{"checksum", "1 1 1 1 1 1 1 1 1 1 1 1", bip39.English, bip39.IndexOneBased, "", bip39.ErrInvalidMnemonic},
+		{"word count", "1 1 1", bip39.English, bip39.IndexOneBased, "", bip39.ErrInvalidMnemonic},
+		{"format", "1", bip39.English, bip39.IndexHex + 1, "", bip39.ErrIndexFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bip39.DecodeIndices(tt.s, tt.lang, tt.format)
+			if err != tt.err {
+				t.Fatalf("DecodeIndices() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeIndices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndicesRoundTrip(t *testing.T) {
+	for _, lang := range bip39.All() {
+		mnemonic, _ := bip39.NewMnemonic(24, lang)
+		indices, err := bip39.MnemonicToIndices(mnemonic, lang)
+		if err != nil {
+			t.Fatalf("%v: %v", lang, err)
+		}
+		got, err := bip39.IndicesToMnemonic(indices, lang)
+		if err != nil {
+			t.Fatalf("%v: %v", lang, err)
+		}
+		if got != mnemonic {
+			t.Errorf("%v: IndicesToMnemonic() = %v, want %v", lang, got, mnemonic)
+		}
+	}
+	if _, err := bip39.MnemonicToIndices("abandon", bip39.English); err != bip39.ErrInvalidMnemonic {
+		t.Errorf("MnemonicToIndices() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+	}
+}
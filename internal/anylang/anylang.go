@@ -0,0 +1,15 @@
+// Package anylang checks mnemonics given without their language.
+package anylang
+
+import "github.com/adesight/bip39"
+
+// IsMnemonicValid reports whether mnemonic is valid in one of the BIP39
+// word lists
+func IsMnemonicValid(mnemonic string) bool {
+	for _, lang := range bip39.All() {
+		if bip39.IsMnemonicValid(mnemonic, lang) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Package base58 implements the Bitcoin base58 and base58check encodings.
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Error list
+var (
+	ErrInvalidChar     = errors.New("Invalid base58 character")
+	ErrInvalidChecksum = errors.New("Invalid base58 checksum")
+)
+
+var (
+	radix   = big.NewInt(58)
+	indices [256]int8
+)
+
+func init() {
+	for i := range indices {
+		indices[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		indices[alphabet[i]] = int8(i)
+	}
+}
+
+// Encode encodes data as base58
+func Encode(data []byte) string {
+	x := new(big.Int).SetBytes(data)
+	mod := new(big.Int)
+
+	var out []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	// leading zero bytes are encoded as leading '1's
+	for _, v := range data {
+		if v != 0 {
+			break
+		}
+		out = append(out, alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Decode decodes a base58 string
+func Decode(s string) ([]byte, error) {
+	x := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		idx := indices[s[i]]
+		if idx < 0 {
+			return nil, ErrInvalidChar
+		}
+		x.Mul(x, radix)
+		x.Add(x, big.NewInt(int64(idx)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
+
+// CheckEncode appends a 4 bytes double SHA256 checksum to data and encodes it as base58
+func CheckEncode(data []byte) string {
+	return Encode(append(append([]byte(nil), data...), checksum(data)...))
+}
+
+// CheckDecode decodes a base58check string and strips its checksum
+func CheckDecode(s string) ([]byte, error) {
+	data, err := Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, ErrInvalidChecksum
+	}
+	payload := data[:len(data)-4]
+	if !bytes.Equal(data[len(data)-4:], checksum(payload)) {
+		return nil, ErrInvalidChecksum
+	}
+	return payload, nil
+}
+
+func checksum(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
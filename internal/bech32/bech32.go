@@ -0,0 +1,121 @@
+// Package bech32 implements the BIP173 bech32 encoding of byte strings,
+// as used by Cosmos addresses and Nostr keys.
+package bech32
+
+import (
+	"errors"
+	"strings"
+)
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Error list
+var (
+	ErrInvalidLength   = errors.New("Invalid bech32 string length")
+	ErrInvalidCase     = errors.New("Invalid bech32 mixed case string")
+	ErrInvalidChar     = errors.New("Invalid bech32 character")
+	ErrInvalidChecksum = errors.New("Invalid bech32 checksum")
+	ErrInvalidPadding  = errors.New("Invalid bech32 padding")
+)
+
+var generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// Encode encodes data under the human readable part hrp
+func Encode(hrp string, data []byte) string {
+	values := convertBits(data, 8, 5, true)
+	hrp = strings.ToLower(hrp)
+
+	chk := polymod(append(append(hrpExpand(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(charset[(chk>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// Decode decodes a bech32 string into its human readable part and data
+func Decode(s string) (string, []byte, error) {
+	if len(s) < 8 {
+		return "", nil, ErrInvalidLength
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, ErrInvalidCase
+	}
+	pos := strings.LastIndexByte(lower, '1')
+	if pos < 1 || pos+7 > len(lower) {
+		return "", nil, ErrInvalidLength
+	}
+	hrp := lower[:pos]
+	values := make([]byte, 0, len(lower)-pos-1)
+	for i := pos + 1; i < len(lower); i++ {
+		idx := strings.IndexByte(charset, lower[i])
+		if idx < 0 {
+			return "", nil, ErrInvalidChar
+		}
+		values = append(values, byte(idx))
+	}
+	if polymod(append(hrpExpand(hrp), values...)) != 1 {
+		return "", nil, ErrInvalidChecksum
+	}
+
+	data := convertBits(values[:len(values)-6], 5, 8, false)
+	if data == nil {
+		return "", nil, ErrInvalidPadding
+	}
+	return hrp, data, nil
+}
+
+// convertBits regroups bits, returning nil for invalid padding
+func convertBits(data []byte, from, to uint, pad bool) []byte {
+	var acc, bits uint
+	maxv := uint(1)<<to - 1
+	out := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, v := range data {
+		acc = acc<<from | uint(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil
+	}
+	return out
+}
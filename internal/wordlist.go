@@ -1,123 +1,84 @@
 package main
 
 import (
-	"context"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
-	"sync"
 )
 
-const url = "https://raw.githubusercontent.com/bitcoin/bips/master/bip-0039/"
+// srcDir holds the word lists vendored from
+// https://github.com/bitcoin/bips/tree/master/bip-0039
+const srcDir = "bip-0039"
 
-var langs = []string{
-	"chinese_simplified", "chinese_traditional",
-	"english", "french", "italian",
-	"japanese", "korean", "spanish",
+const dirName = "wordlist"
+
+type lang struct {
+	name       string
+	exportName string
+	// sha256 is the pinned digest of the upstream .txt file
+	sha256 string
 }
 
-const dirName = "wordlist"
+var langs = []lang{
+	{"chinese_simplified", "ChineseSimplified", "5c5942792bd8340cb8b27cd592f1015edf56a8c5b26276ee18a482428e7c5726"},
+	{"chinese_traditional", "ChineseTraditional", "417b26b3d8500a4ae3d59717d7011952db6fc2fb84b807f3f94ac734e89c1b5f"},
+	{"english", "English", "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda"},
+	{"french", "French", "ebc3959ab7801a1df6bac4fa7d970652f1df76b683cd2f4003c941c63d517e59"},
+	{"italian", "Italian", "d392c49fdb700a24cd1fceb237c1f65dcc128f6b34a8aacb58b59384b5c648c2"},
+	{"japanese", "Japanese", "2eed0aef492291e061633d7ad8117f1a2b03eb80a29d0e4e3117ac2528d05ffd"},
+	{"korean", "Korean", "9e95f86c167de88f450f0aaf89e87f6624a57f973c67b516e338e8e8b8897f60"},
+	{"spanish", "Spanish", "46846a5a0139d1e3cb77293e521c2865f7bcdb82c44e8d0a06a2cd0ecba48c0b"},
+}
 
 func main() {
+	if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
+		log.Fatal(err)
+	}
 
-	{
-		// clean old data
-		fd, err := os.Open(dirName)
-		if err == nil {
-			if err := os.RemoveAll(dirName); err != nil {
-				log.Fatal(err)
-			}
-		}
-		fd.Close()
+	var digests strings.Builder
+	digests.WriteString("package wordlist\n\n")
+	digests.WriteString("// SHA256 is the pinned digest of each upstream .txt word list, keyed by file name\n")
+	digests.WriteString("var SHA256 = map[string]string{\n")
 
-		// create new word list dir
-		if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
+	for _, lang := range langs {
+		src, err := ioutil.ReadFile(filepath.Join(srcDir, lang.name+".txt"))
+		if err != nil {
 			log.Fatal(err)
 		}
-	}
 
-	errchan := make(chan error)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go func() {
-		defer close(errchan)
-		wg := new(sync.WaitGroup)
-		wg.Add(len(langs))
-		for idx, lang := range langs {
-			go func(idx int, lang string) {
-				defer wg.Done()
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
-				resp, err := http.Get(url + lang + ".txt")
-				if err != nil {
-					errchan <- err
-					return
-				}
-				defer resp.Body.Close()
-
-				src, err := ioutil.ReadAll(resp.Body)
-				if err != nil {
-					errchan <- err
-					return
-				}
-
-				var exportName string
-				switch idx {
-				case 0:
-					exportName = "ChineseSimplified"
-				case 1:
-					exportName = "ChineseTraditional"
-				case 2:
-					exportName = "English"
-				case 3:
-					exportName = "French"
-				case 4:
-					exportName = "Italian"
-				case 5:
-					exportName = "Japanese"
-				case 6:
-					exportName = "Korean"
-				case 7:
-					exportName = "Spanish"
-				}
-
-				content := fmt.Sprintf("package wordlist\n var %s = []string{", exportName)
-
-				res := strings.Split(string(src), "\n")
-				for _, v := range res {
-					if v == "" {
-						continue
-					}
-					content += `"` + v + `", `
-				}
+		// never generate code from a list that differs from the pinned one
+		sum := sha256.Sum256(src)
+		if got := hex.EncodeToString(sum[:]); got != lang.sha256 {
+			log.Fatalf("%s.txt: sha256 %s does not match pinned %s", lang.name, got, lang.sha256)
+		}
 
-				content += "}"
+		words := strings.Split(strings.TrimSuffix(string(src), "\n"), "\n")
+		if len(words) != 2048 {
+			log.Fatalf("%s.txt: %d words, want 2048", lang.name, len(words))
+		}
 
-				if err := ioutil.WriteFile(dirName+"/"+lang+".go", []byte(content), os.ModePerm); err != nil {
-					errchan <- err
-					return
-				}
-			}(idx, lang)
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "package wordlist\n\nvar %s = []string{", lang.exportName)
+		for _, v := range words {
+			fmt.Fprintf(&content, "%q, ", v)
 		}
-		wg.Wait()
-	}()
+		content.WriteString("}\n")
 
-	var err error
-	for v := range errchan {
-		if v != nil {
-			err = v
-			cancel()
+		if err := ioutil.WriteFile(filepath.Join(dirName, lang.name+".go"), content.Bytes(), os.ModePerm); err != nil {
+			log.Fatal(err)
 		}
+		fmt.Fprintf(&digests, "%q: %q,\n", lang.name, lang.sha256)
 	}
 
-	if err != nil {
+	digests.WriteString("}\n")
+	if err := ioutil.WriteFile(filepath.Join(dirName, "sha256.go"), []byte(digests.String()), os.ModePerm); err != nil {
 		log.Fatal(err)
 	}
 
@@ -0,0 +1,52 @@
+package wordlist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var lists = map[string][]string{
+	"chinese_simplified":  ChineseSimplified,
+	"chinese_traditional": ChineseTraditional,
+	"english":             English,
+	"french":              French,
+	"italian":             Italian,
+	"japanese":            Japanese,
+	"korean":              Korean,
+	"spanish":             Spanish,
+}
+
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestSHA256(t *testing.T) {
+	if len(SHA256) != len(lists) {
+		t.Fatalf("SHA256 has %v entries, want %v", len(SHA256), len(lists))
+	}
+	for name, words := range lists {
+		want, has := SHA256[name]
+		if !has {
+			t.Errorf("%v: no pinned digest", name)
+			continue
+		}
+
+		// the generated list must serialize back to the pinned upstream file
+		if got := digest([]byte(strings.Join(words, "\n") + "\n")); got != want {
+			t.Errorf("%v: generated list sha256 = %v, want %v", name, got, want)
+		}
+
+		src, err := ioutil.ReadFile(filepath.Join("..", "bip-0039", name+".txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := digest(src); got != want {
+			t.Errorf("%v.txt sha256 = %v, want %v", name, got, want)
+		}
+	}
+}
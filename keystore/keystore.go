@@ -0,0 +1,299 @@
+// Package keystore encrypts keys and mnemonics at rest.
+//
+// Accounts are exported as Web3 Secret Storage V3 files, the format of geth
+// and clef keystores: a scrypt or PBKDF2 derived key, AES-128-CTR and a
+// Keccak-256 MAC. Mnemonics are stored in an envelope sharing the same
+// crypto section.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/adesight/bip39/bip32"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// Version is the Web3 Secret Storage version written and read
+const Version = 3
+
+// KDF names
+const (
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+)
+
+const (
+	cipherName = "aes-128-ctr"
+	prfName    = "hmac-sha256"
+	dkLen      = 32
+)
+
+// Highest KDF costs accepted from files, far above the standard parameters
+// but bounding the memory and time an untrusted file can make Decrypt spend
+const (
+	maxScryptN = 1 << 20
+	maxScryptR = 8
+	maxScryptP = 16
+	maxPBKDF2C = 10000000
+)
+
+// Error list
+var (
+	ErrInvalidPassword = errors.New("Invalid password or MAC mismatch")
+	ErrVersion         = errors.New("Unsupported keystore version")
+	ErrCipher          = errors.New("Unsupported keystore cipher")
+	ErrKDF             = errors.New("Unsupported or invalid keystore KDF parameters")
+	ErrPrivateKey      = errors.New("Invalid private key")
+)
+
+// KDFParams selects the key derivation of new files
+type KDFParams struct {
+	KDF string
+	// scrypt cost parameters
+	N, R, P int
+	// PBKDF2 iterations
+	C int
+}
+
+// Standard parameters, as geth's standard and light scrypt settings
+var (
+	StandardScrypt = KDFParams{KDF: KDFScrypt, N: 1 << 18, R: 8, P: 1}
+	LightScrypt    = KDFParams{KDF: KDFScrypt, N: 1 << 12, R: 8, P: 6}
+	StandardPBKDF2 = KDFParams{KDF: KDFPBKDF2, C: 262144}
+)
+
+// randReader is the source of salts, IVs and ids
+var randReader io.Reader = rand.Reader
+
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    kdfParamsJSON    `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type kdfParamsJSON struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n,omitempty"`
+	R     int    `json:"r,omitempty"`
+	P     int    `json:"p,omitempty"`
+	C     int    `json:"c,omitempty"`
+	PRF   string `json:"prf,omitempty"`
+	Salt  string `json:"salt"`
+}
+
+type keyJSON struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+// Encrypt encrypts a 32 bytes secp256k1 private key as a V3 keystore file
+func Encrypt(privateKey []byte, password string, params KDFParams) ([]byte, error) {
+	address, err := Address(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	c, err := encrypt(privateKey, nil, password, params)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(keyJSON{
+		Address: address[2:],
+		Crypto:  *c,
+		ID:      id,
+		Version: Version,
+	}, "", "  ")
+}
+
+// Decrypt recovers the private key of a V3 keystore file
+func Decrypt(data []byte, password string) ([]byte, error) {
+	var k keyJSON
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, err
+	}
+	if k.Version != Version {
+		return nil, ErrVersion
+	}
+	key, err := decrypt(&k.Crypto, nil, password)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Address(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ExportAccount encrypts the Ethereum account m/44'/60'/0'/0/index of a
+// mnemonic. param passwd is the BIP39 passphrase and can be empty string
+func ExportAccount(mnemonic string, passwd string, index uint32, password string, params KDFParams) ([]byte, error) {
+	master, err := bip32.NewMasterKeyFromMnemonic(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.Derive(fmt.Sprintf("m/44'/60'/0'/0/%d", index))
+	if err != nil {
+		return nil, err
+	}
+	return Encrypt(key.PrivateKey(), password, params)
+}
+
+// Address returns the lowercase hex Ethereum address of a private key
+func Address(privateKey []byte) (string, error) {
+	var k secp256k1.ModNScalar
+	if len(privateKey) != 32 || k.SetByteSlice(privateKey) || k.IsZero() {
+		return "", ErrPrivateKey
+	}
+	pub := secp256k1.PrivKeyFromBytes(privateKey).PubKey().SerializeUncompressed()
+	return "0x" + hex.EncodeToString(keccak256(pub[1:])[12:]), nil
+}
+
+// encrypt encrypts plaintext, the MAC also covering ad. V3 key files have no
+// additional data, so that their MAC stays the standard one
+func encrypt(plaintext, ad []byte, password string, params KDFParams) (*cryptoJSON, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(randReader, salt); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(randReader, iv); err != nil {
+		return nil, err
+	}
+
+	kp := kdfParamsJSON{DKLen: dkLen, Salt: hex.EncodeToString(salt)}
+	switch params.KDF {
+	case KDFScrypt:
+		kp.N, kp.R, kp.P = params.N, params.R, params.P
+	case KDFPBKDF2:
+		kp.C, kp.PRF = params.C, prfName
+	default:
+		return nil, ErrKDF
+	}
+	derived, err := deriveKey(params.KDF, &kp, password)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := aesCTR(derived[:16], iv, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return &cryptoJSON{
+		Cipher:       cipherName,
+		CipherText:   hex.EncodeToString(ciphertext),
+		CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+		KDF:          params.KDF,
+		KDFParams:    kp,
+		MAC:          hex.EncodeToString(keccak256(derived[16:32], ciphertext, ad)),
+	}, nil
+}
+
+func decrypt(c *cryptoJSON, ad []byte, password string) ([]byte, error) {
+	if c.Cipher != cipherName {
+		return nil, ErrCipher
+	}
+	mac, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := hex.DecodeString(c.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := deriveKey(c.KDF, &c.KDFParams, password)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(keccak256(derived[16:32], ciphertext, ad), mac) {
+		return nil, ErrInvalidPassword
+	}
+	return aesCTR(derived[:16], iv, ciphertext)
+}
+
+func deriveKey(kdf string, kp *kdfParamsJSON, password string) ([]byte, error) {
+	salt, err := hex.DecodeString(kp.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if kp.DKLen != dkLen {
+		return nil, ErrKDF
+	}
+	switch kdf {
+	case KDFScrypt:
+		if kp.N > maxScryptN || kp.R > maxScryptR || kp.P > maxScryptP {
+			return nil, ErrKDF
+		}
+		key, err := scrypt.Key([]byte(password), salt, kp.N, kp.R, kp.P, kp.DKLen)
+		if err != nil {
+			return nil, ErrKDF
+		}
+		return key, nil
+	case KDFPBKDF2:
+		if kp.PRF != prfName || kp.C < 1 || kp.C > maxPBKDF2C {
+			return nil, ErrKDF
+		}
+		return pbkdf2.Key([]byte(password), salt, kp.C, kp.DKLen, sha256.New), nil
+	}
+	return nil, ErrKDF
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, ErrCipher
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() (string, error) {
+	u := make([]byte, 16)
+	if _, err := io.ReadFull(randReader, u); err != nil {
+		return "", err
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
@@ -0,0 +1,148 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Test vectors of the Web3 Secret Storage Definition
+const (
+	testPassword = "testpassword"
+	testKey      = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+
+	pbkdf2Vector = `{
+		"crypto" : {
+			"cipher" : "aes-128-ctr",
+			"cipherparams" : {"iv" : "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext" : "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf" : "pbkdf2",
+			"kdfparams" : {
+				"c" : 262144,
+				"dklen" : 32,
+				"prf" : "hmac-sha256",
+				"salt" : "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac" : "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id" : "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version" : 3
+	}`
+
+	scryptVector = `{
+		"crypto" : {
+			"cipher" : "aes-128-ctr",
+			"cipherparams" : {"iv" : "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext" : "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf" : "scrypt",
+			"kdfparams" : {
+				"dklen" : 32,
+				"n" : 262144,
+				"r" : 1,
+				"p" : 8,
+				"salt" : "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+			},
+			"mac" : "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id" : "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version" : 3
+	}`
+)
+
+func TestDecrypt(t *testing.T) {
+	want, _ := hex.DecodeString(testKey)
+	for name, vector := range map[string]string{"pbkdf2": pbkdf2Vector, "scrypt": scryptVector} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Decrypt([]byte(vector), testPassword)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Decrypt() = %x, want %x", got, want)
+			}
+			if _, err := Decrypt([]byte(vector), "wrong"); err != ErrInvalidPassword {
+				t.Errorf("Decrypt() error = %v, want %v", err, ErrInvalidPassword)
+			}
+		})
+	}
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		vector  string
+		old     string
+		new     string
+		wantErr error
+	}{
+		{"version", pbkdf2Vector, `"version" : 3`, `"version" : 1`, ErrVersion},
+		{"cipher", pbkdf2Vector, `"aes-128-ctr"`, `"aes-128-cbc"`, ErrCipher},
+		{"kdf", pbkdf2Vector, `"pbkdf2"`, `"argon2"`, ErrKDF},
+		{"prf", pbkdf2Vector, `"hmac-sha256"`, `"hmac-sha512"`, ErrKDF},
+		{"dklen", pbkdf2Vector, `"dklen" : 32`, `"dklen" : 16`, ErrKDF},
+		{"pbkdf2 c", pbkdf2Vector, `"c" : 262144`, `"c" : 10000001`, ErrKDF},
+		{"scrypt n", scryptVector, `"n" : 262144`, `"n" : 2097152`, ErrKDF},
+		{"scrypt r", scryptVector, `"r" : 1`, `"r" : 9`, ErrKDF},
+		{"scrypt p", scryptVector, `"p" : 8`, `"p" : 17`, ErrKDF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(tt.vector, tt.old, tt.new, 1)
+			if data == tt.vector {
+				t.Fatalf("%v not found", tt.old)
+			}
+			if _, err := Decrypt([]byte(data), testPassword); err != tt.wantErr {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	key, _ := hex.DecodeString(testKey)
+	for _, params := range []KDFParams{LightScrypt, {KDF: KDFPBKDF2, C: 1024}} {
+		t.Run(params.KDF, func(t *testing.T) {
+			data, err := Encrypt(key, testPassword, params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Decrypt(data, testPassword)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, key) {
+				t.Errorf("Decrypt() = %x, want %x", got, key)
+			}
+			if !strings.Contains(string(data), `"address": "008aeeda4d805471df9b2a5b0f38a0c3bcba786b"`) {
+				t.Errorf("Encrypt() = %s", data)
+			}
+		})
+	}
+
+	if _, err := Encrypt(key, testPassword, KDFParams{KDF: "argon2"}); err != ErrKDF {
+		t.Errorf("Encrypt() error = %v, want %v", err, ErrKDF)
+	}
+	if _, err := Encrypt(make([]byte, 32), testPassword, LightScrypt); err != ErrPrivateKey {
+		t.Errorf("Encrypt() error = %v, want %v", err, ErrPrivateKey)
+	}
+}
+
+func TestExportAccount(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+	data, err := ExportAccount(mnemonic, "", 0, testPassword, LightScrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := Decrypt(data, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// first Hardhat and Anvil development account
+	if got := hex.EncodeToString(key); got != "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80" {
+		t.Errorf("ExportAccount() key = %v", got)
+	}
+	if addr, _ := Address(key); addr != "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266" {
+		t.Errorf("Address() = %v", addr)
+	}
+}
@@ -0,0 +1,80 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/adesight/bip39"
+)
+
+// MnemonicVersion is the mnemonic envelope version written and read
+const MnemonicVersion = 1
+
+const mnemonicType = "bip39-mnemonic"
+
+// Error list
+var (
+	ErrEnvelopeType  = errors.New("Invalid mnemonic envelope type")
+	ErrEnvelopeWords = errors.New("Invalid mnemonic envelope word count")
+)
+
+type mnemonicJSON struct {
+	Type     string         `json:"type"`
+	Language bip39.Language `json:"language"`
+	Words    int            `json:"words"`
+	Crypto   cryptoJSON     `json:"crypto"`
+	ID       string         `json:"id"`
+	Version  int            `json:"version"`
+}
+
+// EncryptMnemonic encrypts the entropy of a mnemonic in a JSON envelope with
+// the crypto section of V3 keystore files. The MAC also covers the language,
+// which is stored in clear.
+func EncryptMnemonic(mnemonic string, lang bip39.Language, password string, params KDFParams) ([]byte, error) {
+	entropy, err := bip39.MnemonicToEntropy(mnemonic, lang)
+	if err != nil {
+		return nil, err
+	}
+	c, err := encrypt(entropy, []byte(lang.Code()), password, params)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(mnemonicJSON{
+		Type:     mnemonicType,
+		Language: lang,
+		Words:    len(entropy) * 3 / 4,
+		Crypto:   *c,
+		ID:       id,
+		Version:  MnemonicVersion,
+	}, "", "  ")
+}
+
+// DecryptMnemonic recovers the mnemonic and its language from an envelope
+func DecryptMnemonic(data []byte, password string) (string, bip39.Language, error) {
+	var m mnemonicJSON
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", 0, err
+	}
+	if m.Type != mnemonicType {
+		return "", 0, ErrEnvelopeType
+	}
+	if m.Version != MnemonicVersion {
+		return "", 0, ErrVersion
+	}
+	entropy, err := decrypt(&m.Crypto, []byte(m.Language.Code()), password)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(entropy)*3/4 != m.Words {
+		return "", 0, ErrEnvelopeWords
+	}
+	mnemonic, err := bip39.NewMnemonicByEntropy(entropy, m.Language)
+	if err != nil {
+		return "", 0, err
+	}
+	return mnemonic, m.Language, nil
+}
@@ -0,0 +1,75 @@
+package keystore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestEncryptMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		lang     bip39.Language
+	}{
+		{"english", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", bip39.English},
+		{"japanese", "そつう　れきだい　ほんやく　わかす　りくつ　ばいか　ろせん　やちん　そつう　れきだい　ほんやく　わかめ", bip39.Japanese},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncryptMnemonic(tt.mnemonic, tt.lang, testPassword, LightScrypt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(data), strings.Fields(tt.mnemonic)[0]) {
+				t.Errorf("EncryptMnemonic() leaks words: %s", data)
+			}
+			got, lang, err := DecryptMnemonic(data, testPassword)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// wordlists are stored NFKD
+			if norm.NFKD.String(got) != norm.NFKD.String(tt.mnemonic) || lang != tt.lang {
+				t.Errorf("DecryptMnemonic() = %v, %v, want %v, %v", got, lang, tt.mnemonic, tt.lang)
+			}
+			if _, _, err := DecryptMnemonic(data, "wrong"); err != ErrInvalidPassword {
+				t.Errorf("DecryptMnemonic() error = %v, want %v", err, ErrInvalidPassword)
+			}
+		})
+	}
+}
+
+func TestDecryptMnemonicInvalid(t *testing.T) {
+	if _, err := EncryptMnemonic("abandon abandon abandon", bip39.English, testPassword, LightScrypt); err != bip39.ErrInvalidMnemonic {
+		t.Errorf("EncryptMnemonic() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+	}
+	if _, _, err := DecryptMnemonic([]byte(pbkdf2Vector), testPassword); err != ErrEnvelopeType {
+		t.Errorf("DecryptMnemonic() error = %v, want %v", err, ErrEnvelopeType)
+	}
+
+	data, err := EncryptMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", bip39.English, testPassword, LightScrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		old, new string
+		want     error
+	}{
+		{"language", `"language": "english"`, `"language": "french"`, ErrInvalidPassword},
+		{"words", `"words": 12`, `"words": 24`, ErrEnvelopeWords},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := strings.Replace(string(data), tt.old, tt.new, 1)
+			if tampered == string(data) {
+				t.Fatalf("%v not found in %s", tt.old, data)
+			}
+			if _, _, err := DecryptMnemonic([]byte(tampered), testPassword); err != tt.want {
+				t.Errorf("DecryptMnemonic() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package bip39
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// KnownReason tells why a mnemonic is considered compromised
+type KnownReason uint8
+
+// KnownReason list
+const (
+	// ReasonPublished is a mnemonic of test vectors, tutorials or
+	// development tools
+	ReasonPublished KnownReason = iota
+	// ReasonLowComplexity is a mnemonic of patterned entropy or few words
+	ReasonLowComplexity
+	// ReasonDenyList is a mnemonic of an organization's deny list
+	ReasonDenyList
+)
+
+func (r KnownReason) String() string {
+	switch r {
+	case ReasonPublished:
+		return "published"
+	case ReasonLowComplexity:
+		return "low complexity"
+	case ReasonDenyList:
+		return "deny list"
+	}
+	return fmt.Sprintf("KnownReason(%d)", r)
+}
+
+// KnownMnemonicError reports a mnemonic that must not hold funds
+type KnownMnemonicError struct {
+	Reason KnownReason
+	Detail string
+}
+
+func (e *KnownMnemonicError) Error() string {
+	return fmt.Sprintf("Known mnemonic (%v): %s", e.Reason, e.Detail)
+}
+
+// publishedMnemonics are well known phrases of development tools and
+// documentation, in addition to the test vector entropies of weakEntropies
+var publishedMnemonics = []string{
+	// Hardhat, Anvil and Foundry default accounts
+	"test test test test test test test test test test test junk",
+	// Trezor emulator and firmware tests
+	"all all all all all all all all all all all all",
+	// Ganache deterministic mode
+	"myth like bonus scare over problem client lizard pioneer submit female collect",
+	// Truffle develop
+	"candy maple cake sugar pudding cream honey rich smooth crumble sweet treat",
+}
+
+// knownEntropies holds the fingerprints of every published entropy
+var knownEntropies = func() map[[32]byte]bool {
+	m := make(map[[32]byte]bool)
+	for _, e := range weakEntropies {
+		m[sha256.Sum256(e)] = true
+	}
+	for _, s := range publishedMnemonics {
+		e, err := MnemonicToEntropy(s, English)
+		if err != nil {
+			panic(err)
+		}
+		m[sha256.Sum256(e)] = true
+	}
+	return m
+}()
+
+// CheckKnownMnemonic returns a *KnownMnemonicError if a valid mnemonic is
+// published, e.g. as a test vector, or has low complexity entropy, and
+// ErrInvalidMnemonic if it is not valid. Published mnemonics are matched by
+// entropy, so their translations are found too.
+func CheckKnownMnemonic(mnemonic string, lang Language) error {
+	entropy, err := MnemonicToEntropy(mnemonic, lang)
+	if err != nil {
+		return err
+	}
+	if knownEntropies[sha256.Sum256(entropy)] {
+		return &KnownMnemonicError{ReasonPublished, "public test vector or development phrase"}
+	}
+
+	if e, ok := CheckEntropy(entropy).(*EntropyQualityError); ok && e.Severity == SeverityError {
+		return &KnownMnemonicError{ReasonLowComplexity, e.Error()}
+	}
+	// a random mnemonic repeats a word with a few percent chance, a third
+	// of distinct words never happens
+	words := strings.Fields(mnemonic)
+	distinct := make(map[string]bool, len(words))
+	for _, w := range words {
+		distinct[w] = true
+	}
+	if len(distinct) <= len(words)/3 {
+		return &KnownMnemonicError{ReasonLowComplexity,
+			fmt.Sprintf("%d distinct words of %d", len(distinct), len(words))}
+	}
+	return nil
+}
+
+// EntropyFingerprint returns the hex SHA256 of the entropy of a mnemonic, the
+// form of deny list entries that do not reveal the mnemonic
+func EntropyFingerprint(mnemonic string, lang Language) (string, error) {
+	entropy, err := MnemonicToEntropy(mnemonic, lang)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(entropy)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DenyListError reports an invalid line of a deny list
+type DenyListError struct {
+	Line   int
+	Reason string
+}
+
+func (e *DenyListError) Error() string {
+	return fmt.Sprintf("Invalid deny list entry at line %d: %s", e.Line, e.Reason)
+}
+
+// DenyList is an organization specific list of refused mnemonics
+type DenyList struct {
+	entries map[[32]byte]bool
+}
+
+// LoadDenyList reads a deny list, one entry per line: a mnemonic in lang or
+// "sha256:" followed by its EntropyFingerprint. Blank lines and lines
+// starting with "#" are ignored.
+func LoadDenyList(r io.Reader, lang Language) (*DenyList, error) {
+	d := &DenyList{entries: make(map[[32]byte]bool)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+
+		var sum [32]byte
+		if strings.HasPrefix(s, "sha256:") {
+			b, err := hex.DecodeString(s[len("sha256:"):])
+			if err != nil || len(b) != len(sum) {
+				return nil, &DenyListError{line, "invalid sha256 fingerprint"}
+			}
+			copy(sum[:], b)
+		} else {
+			entropy, err := MnemonicToEntropy(strings.Join(strings.Fields(s), lang.Separator()), lang)
+			if err != nil {
+				return nil, &DenyListError{line, "invalid mnemonic"}
+			}
+			sum = sha256.Sum256(entropy)
+		}
+		d.entries[sum] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// LoadDenyListFile reads a deny list file, see LoadDenyList
+func LoadDenyListFile(path string, lang Language) (*DenyList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadDenyList(f, lang)
+}
+
+// Len returns the number of entries
+func (d *DenyList) Len() int {
+	return len(d.entries)
+}
+
+// Check is CheckKnownMnemonic extended with the deny list
+func (d *DenyList) Check(mnemonic string, lang Language) error {
+	if err := CheckKnownMnemonic(mnemonic, lang); err != nil {
+		return err
+	}
+	entropy, _ := MnemonicToEntropy(mnemonic, lang)
+	if d.entries[sha256.Sum256(entropy)] {
+		return &KnownMnemonicError{ReasonDenyList, "entry of the deny list"}
+	}
+	return nil
+}
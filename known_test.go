@@ -0,0 +1,111 @@
+package bip39_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestCheckKnownMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		lang     bip39.Language
+		reason   bip39.KnownReason
+	}{
+		{"zero entropy", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", bip39.English, bip39.ReasonPublished},
+		{"test vector", "legal winner thank year wave sausage worth useful legal winner thank yellow", bip39.English, bip39.ReasonPublished},
+		{"random test vector", "ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic", bip39.English, bip39.ReasonPublished},
+		{"hardhat", "test test test test test test test test test test test junk", bip39.English, bip39.ReasonPublished},
+		{"trezor", "all all all all all all all all all all all all", bip39.English, bip39.ReasonPublished},
+		{"translated test vector", "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら", bip39.Japanese, bip39.ReasonPublished},
+		{"few words", fewWordsMnemonic(t), bip39.English, bip39.ReasonLowComplexity},
+		{"patterned entropy", mnemonicOf(t, "deadbeefdeadbeefdeadbeefdeadbeef"), bip39.English, bip39.ReasonLowComplexity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bip39.CheckKnownMnemonic(tt.mnemonic, tt.lang)
+			e, ok := err.(*bip39.KnownMnemonicError)
+			if !ok {
+				t.Fatalf("CheckKnownMnemonic() error = %v, want *KnownMnemonicError", err)
+			}
+			if e.Reason != tt.reason {
+				t.Errorf("CheckKnownMnemonic() = %v, want %v", e, tt.reason)
+			}
+		})
+	}
+
+	if err := bip39.CheckKnownMnemonic("abandon abandon", bip39.English); err != bip39.ErrInvalidMnemonic {
+		t.Errorf("CheckKnownMnemonic() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+	}
+	for i := 0; i < 100; i++ {
+		mnemonic, _ := bip39.NewMnemonic(12, bip39.English)
+		if err := bip39.CheckKnownMnemonic(mnemonic, bip39.English); err != nil {
+			t.Errorf("CheckKnownMnemonic(%v) = %v", mnemonic, err)
+		}
+	}
+}
+
+func mnemonicOf(t *testing.T, entropy string) string {
+	mnemonic, err := bip39.NewMnemonicByEntropy(mustDecodeHex(entropy), bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mnemonic
+}
+
+// fewWordsMnemonic alternates two words and completes the checksum
+func fewWordsMnemonic(t *testing.T) string {
+	words := strings.Repeat("wolf hockey ", 12)
+	for _, last := range bip39.English.List() {
+		if m := words[:len(words)-len("hockey ")] + last; bip39.IsMnemonicValid(m, bip39.English) {
+			return m
+		}
+	}
+	t.Fatal("no checksum word")
+	return ""
+}
+
+func TestDenyList(t *testing.T) {
+	denied := mnemonicOf(t, "b2a7c3f0e58d4a1f9c6e0d7b3a8f2e51")
+	hashed := mnemonicOf(t, "4e1d9a7c2b8f6e03d5a9c1f7b2e8d460")
+	fp, err := bip39.EntropyFingerprint(hashed, bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "deny.txt")
+	content := "# leaked in the 2024 incident\n\n  " + denied + "  \nsha256:" + fp + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := bip39.LoadDenyListFile(path, bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 2 {
+		t.Errorf("Len() = %v, want 2", d.Len())
+	}
+	for _, m := range []string{denied, hashed} {
+		if e, ok := d.Check(m, bip39.English).(*bip39.KnownMnemonicError); !ok || e.Reason != bip39.ReasonDenyList {
+			t.Errorf("Check(%v) = %v, want deny list", m, e)
+		}
+	}
+	if e, ok := d.Check("test test test test test test test test test test test junk", bip39.English).(*bip39.KnownMnemonicError); !ok || e.Reason != bip39.ReasonPublished {
+		t.Errorf("Check() = %v, want published", e)
+	}
+	random, _ := bip39.NewMnemonic(12, bip39.English)
+	if err := d.Check(random, bip39.English); err != nil {
+		t.Errorf("Check(%v) = %v", random, err)
+	}
+
+	for _, bad := range []string{"sha256:abcd", "not a mnemonic"} {
+		_, err := bip39.LoadDenyList(strings.NewReader("# header\n"+bad), bip39.English)
+		if e, ok := err.(*bip39.DenyListError); !ok || e.Line != 2 {
+			t.Errorf("LoadDenyList(%q) error = %v", bad, err)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package bip39
 
-import "github.com/adesight/bip39/internal/wordlist"
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/adesight/bip39/internal/wordlist"
+)
 
 // Language is bip39 word lang type
 type Language uint8
@@ -17,6 +23,98 @@ const (
 	Spanish
 )
 
+// ErrUnknownLanguage is returned for unsupported language names and codes
+var ErrUnknownLanguage = errors.New("Unknown language")
+
+type languageInfo struct {
+	name      string // upstream word list file name
+	code      string // BCP-47 language tag
+	separator string // joins the words of a mnemonic
+}
+
+var languages = [...]languageInfo{
+	ChineseSimplified:  {name: "chinese_simplified", code: "zh-Hans", separator: "\x20"},
+	ChineseTraditional: {name: "chinese_traditional", code: "zh-Hant", separator: "\x20"},
+	English:            {name: "english", code: "en", separator: "\x20"},
+	French:             {name: "french", code: "fr", separator: "\x20"},
+	Italian:            {name: "italian", code: "it", separator: "\x20"},
+	// Japanese mnemonics are joined by the ideographic space
+	Japanese: {name: "japanese", code: "ja", separator: "\u3000"},
+	Korean:   {name: "korean", code: "ko", separator: "\x20"},
+	Spanish:  {name: "spanish", code: "es", separator: "\x20"},
+}
+
+// All returns every supported language
+func All() []Language {
+	all := make([]Language, len(languages))
+	for i := range all {
+		all[i] = Language(i)
+	}
+	return all
+}
+
+// ParseLanguage parses a language name like "english" or "chinese_simplified",
+// or a BCP-47 code like "en" or "zh-Hans", case insensitively
+func ParseLanguage(s string) (Language, error) {
+	for i, v := range languages {
+		if strings.EqualFold(s, v.name) || strings.EqualFold(s, v.code) {
+			return Language(i), nil
+		}
+	}
+	return 0, ErrUnknownLanguage
+}
+
+func (lan Language) valid() bool {
+	return int(lan) < len(languages)
+}
+
+// String returns the language name, e.g. "english"
+func (lan Language) String() string {
+	if !lan.valid() {
+		return "Language(" + strconv.Itoa(int(lan)) + ")"
+	}
+	return languages[lan].name
+}
+
+// Code returns the BCP-47 language code, e.g. "en" or "zh-Hans"
+func (lan Language) Code() string {
+	if !lan.valid() {
+		return ""
+	}
+	return languages[lan].code
+}
+
+// Separator returns the string joining the words of a mnemonic
+func (lan Language) Separator() string {
+	if !lan.valid() {
+		return "\x20"
+	}
+	return languages[lan].separator
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (lan Language) MarshalText() ([]byte, error) {
+	if !lan.valid() {
+		return nil, ErrUnknownLanguage
+	}
+	return []byte(lan.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (lan *Language) UnmarshalText(text []byte) error {
+	return lan.Set(string(text))
+}
+
+// Set implements flag.Value
+func (lan *Language) Set(s string) error {
+	v, err := ParseLanguage(s)
+	if err != nil {
+		return err
+	}
+	*lan = v
+	return nil
+}
+
 // List gets word list
 func (lan Language) List() []string {
 	switch lan {
@@ -1,6 +1,8 @@
 package bip39_test
 
 import (
+	"encoding/json"
+	"flag"
 	"reflect"
 	"testing"
 
@@ -68,3 +70,91 @@ func TestLanguage_List(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    bip39.Language
+		wantErr bool
+	}{
+		{s: "english", want: bip39.English},
+		{s: "English", want: bip39.English},
+		{s: "en", want: bip39.English},
+		{s: "ja", want: bip39.Japanese},
+		{s: "zh-Hans", want: bip39.ChineseSimplified},
+		{s: "zh-hant", want: bip39.ChineseTraditional},
+		{s: "chinese_traditional", want: bip39.ChineseTraditional},
+		{s: "ko", want: bip39.Korean},
+		{s: "es", want: bip39.Spanish},
+		{s: "fr", want: bip39.French},
+		{s: "it", want: bip39.Italian},
+		{s: "zh", wantErr: true},
+		{s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := bip39.ParseLanguage(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLanguage(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLanguage(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestLanguage_Metadata(t *testing.T) {
+	all := bip39.All()
+	if len(all) != 8 {
+		t.Fatalf("All() = %v, want 8 languages", all)
+	}
+	for _, lang := range all {
+		if got, err := bip39.ParseLanguage(lang.String()); err != nil || got != lang {
+			t.Errorf("ParseLanguage(%v) = %v, %v", lang, got, err)
+		}
+		if got, err := bip39.ParseLanguage(lang.Code()); err != nil || got != lang {
+			t.Errorf("ParseLanguage(%v) = %v, %v", lang.Code(), got, err)
+		}
+	}
+
+	if got := bip39.Japanese.Separator(); got != "　" {
+		t.Errorf("Japanese.Separator() = %q", got)
+	}
+	if got := bip39.English.Separator(); got != "\x20" {
+		t.Errorf("English.Separator() = %q", got)
+	}
+	if got := bip39.Language(100).String(); got != "Language(100)" {
+		t.Errorf("Language.String() = %v", got)
+	}
+}
+
+func TestLanguage_MarshalText(t *testing.T) {
+	var v struct {
+		Lang bip39.Language `json:"lang"`
+	}
+	v.Lang = bip39.ChineseSimplified
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"lang":"chinese_simplified"}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"lang":"ko"}`), &v); err != nil || v.Lang != bip39.Korean {
+		t.Errorf("json.Unmarshal() = %v, %v", v.Lang, err)
+	}
+	if err := json.Unmarshal([]byte(`{"lang":"klingon"}`), &v); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error")
+	}
+	if _, err := bip39.Language(100).MarshalText(); err != bip39.ErrUnknownLanguage {
+		t.Errorf("MarshalText() error = %v, want %v", err, bip39.ErrUnknownLanguage)
+	}
+
+	lang := bip39.English
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&lang, "lang", "")
+	if err := fs.Parse([]string{"-lang", "es"}); err != nil || lang != bip39.Spanish {
+		t.Errorf("flag.Parse() = %v, %v", lang, err)
+	}
+}
@@ -0,0 +1,167 @@
+package bip39
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transformation is a change Normalize applied to a mnemonic
+type Transformation uint8
+
+// Transformation list, in the order they are applied
+const (
+	FoldedWidth Transformation = iota
+	NormalizedWhitespace
+	StrippedNumbering
+	StrippedPunctuation
+	Lowercased
+	SplitCharacters
+	RestoredAccents
+)
+
+var transformationNames = [...]string{
+	FoldedWidth:          "converted full-width and compatibility characters",
+	NormalizedWhitespace: "normalized whitespace and word separators",
+	StrippedNumbering:    "removed word numbering",
+	StrippedPunctuation:  "removed punctuation",
+	Lowercased:           "converted to lower case",
+	SplitCharacters:      "split Chinese characters into words",
+	RestoredAccents:      "restored accents",
+}
+
+// String describes the transformation for display
+func (t Transformation) String() string {
+	if int(t) >= len(transformationNames) {
+		return "unknown transformation"
+	}
+	return transformationNames[t]
+}
+
+// Normalizer cleans up mnemonics pasted or typed by users.
+// Whitespace, separators and Unicode compatibility forms are always normalized
+type Normalizer struct {
+	Numbering   bool // strip word numbering such as "1." or "(12)"
+	Punctuation bool // strip punctuation around words
+	Case        bool // lower case the Latin word lists
+	Accents     bool // restore the accents of bare French and Spanish words
+}
+
+// DefaultNormalizer applies every transformation
+var DefaultNormalizer = Normalizer{Numbering: true, Punctuation: true, Case: true, Accents: true}
+
+// Normalize normalizes a mnemonic with DefaultNormalizer
+func Normalize(mnemonic string, lang Language) (string, []Transformation) {
+	return DefaultNormalizer.Normalize(mnemonic, lang)
+}
+
+// ParseMnemonic normalizes a mnemonic with DefaultNormalizer and validates it
+func ParseMnemonic(mnemonic string, lang Language) (string, []Transformation, error) {
+	return DefaultNormalizer.ParseMnemonic(mnemonic, lang)
+}
+
+// ParseMnemonic normalizes a mnemonic and validates it.
+// The transformations are returned even if the mnemonic is invalid
+func (n Normalizer) ParseMnemonic(mnemonic string, lang Language) (string, []Transformation, error) {
+	normalized, applied := n.Normalize(mnemonic, lang)
+	if !IsMnemonicValid(normalized, lang) {
+		return "", applied, ErrInvalidMnemonic
+	}
+	return normalized, applied, nil
+}
+
+// Normalize returns the mnemonic NFKD normalized with its words joined by
+// the separator of lang, and the transformations applied on the way
+func (n Normalizer) Normalize(mnemonic string, lang Language) (string, []Transformation) {
+	var applied []Transformation
+
+	sep := lang.Separator()
+	if strings.Join(strings.FieldsFunc(mnemonic, unicode.IsSpace), sep) != mnemonic {
+		applied = append(applied, NormalizedWhitespace)
+	}
+
+	// the ideographic space folds to an ASCII space, which is not worth reporting
+	spaced := strings.ReplaceAll(mnemonic, "\u3000", "\x20")
+	if norm.NFD.String(spaced) != norm.NFKD.String(spaced) {
+		applied = append([]Transformation{FoldedWidth}, applied...)
+	}
+
+	words := strings.FieldsFunc(norm.NFKD.String(mnemonic), unicode.IsSpace)
+
+	if n.Numbering {
+		var stripped bool
+		words, stripped = filterWords(words, stripNumbering)
+		if stripped {
+			applied = append(applied, StrippedNumbering)
+		}
+	}
+
+	if n.Punctuation {
+		var stripped bool
+		words, stripped = filterWords(words, func(word string) string {
+			return strings.TrimFunc(word, unicode.IsPunct)
+		})
+		if stripped {
+			applied = append(applied, StrippedPunctuation)
+		}
+	}
+
+	if n.Case && isLatin(lang) {
+		var lowered bool
+		words, lowered = filterWords(words, strings.ToLower)
+		if lowered {
+			applied = append(applied, Lowercased)
+		}
+	}
+
+	// every Chinese word is a single character
+	if lang == ChineseSimplified || lang == ChineseTraditional {
+		split := make([]string, 0, len(words))
+		for _, v := range words {
+			for _, r := range v {
+				split = append(split, string(r))
+			}
+		}
+		if len(split) != len(words) {
+			applied = append(applied, SplitCharacters)
+		}
+		words = split
+	}
+
+	if n.Accents {
+		var restored bool
+		if words, restored = restoreAccents(words, lang); restored {
+			applied = append(applied, RestoredAccents)
+		}
+	}
+
+	return strings.Join(words, sep), applied
+}
+
+// filterWords maps every word with fn and drops the empty results,
+// reporting whether anything changed
+func filterWords(words []string, fn func(string) string) ([]string, bool) {
+	out := words[:0]
+	var changed bool
+	for _, v := range words {
+		w := fn(v)
+		if w != v {
+			changed = true
+		}
+		if w != "" {
+			out = append(out, w)
+		}
+	}
+	return out, changed
+}
+
+// stripNumbering removes a leading "1", "1.", "1)", "(1)", "#1" or "1:"
+func stripNumbering(word string) string {
+	s := strings.TrimLeft(word, "(#")
+	digits := strings.TrimLeftFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if len(digits) == len(s) {
+		return word
+	}
+	return strings.TrimLeft(digits, ".):-")
+}
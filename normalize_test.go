@@ -0,0 +1,98 @@
+package bip39_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adesight/bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestNormalize(t *testing.T) {
+	const english = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	tests := []struct {
+		name     string
+		mnemonic string
+		lang     bip39.Language
+		want     string
+		applied  []bip39.Transformation
+	}{
+		{
+			name:     "canonical",
+			mnemonic: english,
+			lang:     bip39.English,
+			want:     english,
+		},
+		{
+			name:     "whitespace",
+			mnemonic: "  abandon\tabandon  abandon\nabandon abandon abandon\r\nabandon abandon abandon abandon abandon about\n",
+			lang:     bip39.English,
+			want:     english,
+			applied:  []bip39.Transformation{bip39.NormalizedWhitespace},
+		},
+		{
+			name:     "numbering",
+			mnemonic: "1. abandon 2. abandon 3) abandon (4) abandon #5 abandon 6:abandon 7 abandon 8.abandon 9 abandon 10 abandon 11 abandon 12 about",
+			lang:     bip39.English,
+			want:     english,
+			applied:  []bip39.Transformation{bip39.StrippedNumbering},
+		},
+		{
+			name:     "punctuation and case",
+			mnemonic: "Abandon, abandon, abandon, abandon, abandon, abandon, abandon, abandon, abandon, abandon, abandon, ABOUT.",
+			lang:     bip39.English,
+			want:     english,
+			applied:  []bip39.Transformation{bip39.StrippedPunctuation, bip39.Lowercased},
+		},
+		{
+			name:     "full width",
+			mnemonic: "ａｂａｎｄｏｎ abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			lang:     bip39.English,
+			want:     english,
+			applied:  []bip39.Transformation{bip39.FoldedWidth},
+		},
+		{
+			name:     "japanese ascii spaces",
+			mnemonic: "あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あおぞら",
+			lang:     bip39.Japanese,
+			want:     "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			applied:  []bip39.Transformation{bip39.NormalizedWhitespace},
+		},
+		{
+			name:     "chinese without spaces",
+			mnemonic: "的的的的的的的的的的的在",
+			lang:     bip39.ChineseSimplified,
+			want:     "的 的 的 的 的 的 的 的 的 的 的 在",
+			applied:  []bip39.Transformation{bip39.SplitCharacters},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied, err := bip39.ParseMnemonic(tt.mnemonic, tt.lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bip39.IsMnemonicValid(got, tt.lang) || norm.NFKD.String(got) != norm.NFKD.String(tt.want) {
+				t.Errorf("ParseMnemonic() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(applied, tt.applied) {
+				t.Errorf("ParseMnemonic() applied = %v, want %v", applied, tt.applied)
+			}
+		})
+	}
+}
+
+func TestNormalizer(t *testing.T) {
+	n := bip39.Normalizer{}
+	got, applied := n.Normalize("1. Abandon,", bip39.English)
+	if got != "1. Abandon," || applied != nil {
+		t.Errorf("Normalize() = %q, %v", got, applied)
+	}
+
+	if _, applied, err := bip39.ParseMnemonic("abandon  abandon", bip39.English); err != bip39.ErrInvalidMnemonic || len(applied) != 1 {
+		t.Errorf("ParseMnemonic() = %v, %v", applied, err)
+	}
+	if got := bip39.StrippedNumbering.String(); got != "removed word numbering" {
+		t.Errorf("Transformation.String() = %v", got)
+	}
+}
@@ -0,0 +1,73 @@
+package presets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/adesight/bip39/internal/bech32"
+)
+
+// CoinNostr is the SLIP-44 coin type of Nostr keys
+const CoinNostr uint32 = 1237
+
+// NIP-19 bech32 prefixes
+const (
+	hrpNsec = "nsec"
+	hrpNpub = "npub"
+)
+
+// ErrInvalidNostrKey is returned for malformed nsec and npub strings
+var ErrInvalidNostrKey = errors.New("Invalid nostr key")
+
+// Nostr derives NIP-06 keys at m/44'/1237'/account'/0/0, where the index is
+// the account. PublicKey is the 32 bytes x-only key and Address its npub
+var Nostr = &Preset{
+	Name:     "Nostr",
+	CoinType: CoinNostr,
+	derive: func(seed []byte, account uint32) (*Account, error) {
+		path := fmt.Sprintf("m/44'/%d'/%d'/0/0", CoinNostr, account)
+		key, err := secp256k1Key(seed, path)
+		if err != nil {
+			return nil, err
+		}
+		// BIP340 x-only public key drops the parity byte
+		pub := key.PublicKey()[1:]
+		return &Account{
+			Path:       path,
+			PrivateKey: key.PrivateKey(),
+			PublicKey:  pub,
+			Address:    EncodeNpub(pub),
+		}, nil
+	},
+}
+
+// EncodeNsec encodes a 32 bytes private key as nsec
+func EncodeNsec(priv []byte) string {
+	return bech32.Encode(hrpNsec, priv)
+}
+
+// EncodeNpub encodes a 32 bytes x-only public key as npub
+func EncodeNpub(pub []byte) string {
+	return bech32.Encode(hrpNpub, pub)
+}
+
+// DecodeNsec decodes a nsec private key
+func DecodeNsec(s string) ([]byte, error) {
+	return decodeNostrKey(s, hrpNsec)
+}
+
+// DecodeNpub decodes a npub public key
+func DecodeNpub(s string) ([]byte, error) {
+	return decodeNostrKey(s, hrpNpub)
+}
+
+func decodeNostrKey(s, hrp string) ([]byte, error) {
+	got, data, err := bech32.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if got != hrp || len(data) != 32 {
+		return nil, ErrInvalidNostrKey
+	}
+	return data, nil
+}
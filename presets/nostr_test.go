@@ -0,0 +1,60 @@
+package presets
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestNostr(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		priv     string
+		pub      string
+		nsec     string
+		npub     string
+	}{
+		{
+			mnemonic: "leader monkey parrot ring guide accident before fence cannon height naive bean",
+			priv:     "7f7ff03d123792d6ac594bfa67bf6d0c0ab55b6b1fdb6249303fe861f1ccba9a",
+			pub:      "17162c921dc4d2518f9a101db33695df1afb56ab82f5ff3e5da6eec3ca5cd917",
+			nsec:     "nsec10allq0gjx7fddtzef0ax00mdps9t2kmtrldkyjfs8l5xruwvh2dq0lhhkp",
+			npub:     "npub1zutzeysacnf9rru6zqwmxd54mud0k44tst6l70ja5mhv8jjumytsd2x7nu",
+		},
+		{
+			mnemonic: "what bleak badge arrange retreat wolf trade produce cricket blur garlic valid proud rude strong choose busy staff weather area salt hollow arm fade",
+			priv:     "c15d739894c81a2fcfd3a2df85a0d2c0dbc47a280d092799f144d73d7ae78add",
+			pub:      "d41b22899549e1f3d335a31002cfd382174006e166d3e658e3a5eecdb6463573",
+			nsec:     "nsec1c9wh8xy5eqdzln7n5t0ctgxjcrdug73gp5yj0x03gntn67h83twssdfhel",
+			npub:     "npub16sdj9zv4f8sl85e45vgq9n7nsgt5qphpvmf7vk8r5hhvmdjxx4es8rq74h",
+		},
+	}
+	for _, tt := range tests {
+		p, err := Get(CoinNostr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := p.Derive(tt.mnemonic, "", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got.PrivateKey) != tt.priv {
+			t.Errorf("Derive() private key = %x, want %v", got.PrivateKey, tt.priv)
+		}
+		if hex.EncodeToString(got.PublicKey) != tt.pub {
+			t.Errorf("Derive() public key = %x, want %v", got.PublicKey, tt.pub)
+		}
+		if nsec := EncodeNsec(got.PrivateKey); nsec != tt.nsec {
+			t.Errorf("EncodeNsec() = %v, want %v", nsec, tt.nsec)
+		}
+		if got.Address != tt.npub {
+			t.Errorf("Derive() address = %v, want %v", got.Address, tt.npub)
+		}
+
+		if priv, err := DecodeNsec(tt.nsec); err != nil || hex.EncodeToString(priv) != tt.priv {
+			t.Errorf("DecodeNsec() = %x, %v", priv, err)
+		}
+		if _, err := DecodeNpub(tt.nsec); err != ErrInvalidNostrKey {
+			t.Errorf("DecodeNpub() error = %v, want %v", err, ErrInvalidNostrKey)
+		}
+	}
+}
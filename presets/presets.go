@@ -0,0 +1,165 @@
+// Package presets derives accounts of non-Bitcoin chains from BIP39 mnemonics.
+//
+// Presets are keyed by SLIP-44 coin type and follow the derivation path and
+// address format of each ecosystem's reference wallets:
+//
+//	Solana  501  m/44'/501'/x'/0'   SLIP-10 ed25519, base58 public key
+//	Cosmos  118  m/44'/118'/0'/0/x  secp256k1, bech32 of HASH160
+//	Tron    195  m/44'/195'/0'/0/x  secp256k1, base58check of 0x41 || Keccak
+//	Nostr  1237  m/44'/1237'/x'/0/0 secp256k1 NIP-06, bech32 npub
+package presets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/bip32"
+	"github.com/adesight/bip39/internal/anylang"
+	"github.com/adesight/bip39/internal/base58"
+	"github.com/adesight/bip39/internal/bech32"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/sha3"
+)
+
+// SLIP-44 coin types
+const (
+	CoinSolana uint32 = 501
+	CoinCosmos uint32 = 118
+	CoinTron   uint32 = 195
+)
+
+// ErrUnknownCoin is returned for coin types without a preset
+var ErrUnknownCoin = errors.New("Unknown coin type")
+
+// Account is a derived key pair and its address
+type Account struct {
+	Path       string
+	PrivateKey []byte
+	PublicKey  []byte
+	Address    string
+}
+
+// Preset derives the accounts of one chain
+type Preset struct {
+	Name     string
+	CoinType uint32
+	derive   func(seed []byte, index uint32) (*Account, error)
+}
+
+var presets = map[uint32]*Preset{
+	CoinSolana: Solana,
+	CoinCosmos: Cosmos("cosmos"),
+	CoinTron:   Tron,
+	CoinNostr:  Nostr,
+}
+
+// Get returns the preset of a SLIP-44 coin type
+func Get(coinType uint32) (*Preset, error) {
+	p, has := presets[coinType]
+	if !has {
+		return nil, ErrUnknownCoin
+	}
+	return p, nil
+}
+
+// Derive derives the account at index from a mnemonic, which must be valid
+// in one of the BIP39 word lists.
+// param passwd can be empty string
+func (p *Preset) Derive(mnemonic string, passwd string, index uint32) (*Account, error) {
+	if !anylang.IsMnemonicValid(mnemonic) {
+		return nil, bip39.ErrInvalidMnemonic
+	}
+	seed, err := bip39.MnemonicToSeed(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+	return p.DeriveFromSeed(seed, index)
+}
+
+// DeriveFromSeed derives the account at index from a MnemonicToSeed seed
+func (p *Preset) DeriveFromSeed(seed []byte, index uint32) (*Account, error) {
+	if index >= bip32.HardenedOffset {
+		return nil, bip32.ErrInvalidPath
+	}
+	return p.derive(seed, index)
+}
+
+// Solana derives m/44'/501'/x'/0' like Phantom and Solflare
+var Solana = &Preset{
+	Name:     "Solana",
+	CoinType: CoinSolana,
+	derive: func(seed []byte, index uint32) (*Account, error) {
+		path := fmt.Sprintf("m/44'/%d'/%d'/0'", CoinSolana, index)
+		key, err := slip10Ed25519(seed, path)
+		if err != nil {
+			return nil, err
+		}
+		pub := key[32:]
+		return &Account{
+			Path:       path,
+			PrivateKey: key,
+			PublicKey:  append([]byte(nil), pub...),
+			Address:    base58.Encode(pub),
+		}, nil
+	},
+}
+
+// Cosmos returns the preset of a Cosmos SDK chain using coin type 118 with
+// its bech32 prefix, e.g. "cosmos" or "osmo"
+func Cosmos(hrp string) *Preset {
+	return &Preset{
+		Name:     "Cosmos " + hrp,
+		CoinType: CoinCosmos,
+		derive: func(seed []byte, index uint32) (*Account, error) {
+			path := fmt.Sprintf("m/44'/%d'/0'/0/%d", CoinCosmos, index)
+			key, err := secp256k1Key(seed, path)
+			if err != nil {
+				return nil, err
+			}
+			pub := key.PublicKey()
+			return &Account{
+				Path:       path,
+				PrivateKey: key.PrivateKey(),
+				PublicKey:  pub,
+				Address:    bech32.Encode(hrp, bip32.Hash160(pub)),
+			}, nil
+		},
+	}
+}
+
+// Tron derives m/44'/195'/0'/0/x like TronLink
+var Tron = &Preset{
+	Name:     "Tron",
+	CoinType: CoinTron,
+	derive: func(seed []byte, index uint32) (*Account, error) {
+		path := fmt.Sprintf("m/44'/%d'/0'/0/%d", CoinTron, index)
+		key, err := secp256k1Key(seed, path)
+		if err != nil {
+			return nil, err
+		}
+		pub, err := secp256k1.ParsePubKey(key.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+
+		// the address is the last 20 bytes of the Keccak of the uncompressed point
+		h := sha3.NewLegacyKeccak256()
+		h.Write(pub.SerializeUncompressed()[1:])
+		addr := append([]byte{0x41}, h.Sum(nil)[12:]...)
+		return &Account{
+			Path:       path,
+			PrivateKey: key.PrivateKey(),
+			PublicKey:  key.PublicKey(),
+			Address:    base58.CheckEncode(addr),
+		}, nil
+	},
+}
+
+func secp256k1Key(seed []byte, path string) (*bip32.Key, error) {
+	master, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return master.Derive(path)
+}
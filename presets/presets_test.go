@@ -0,0 +1,97 @@
+package presets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		name     string
+		coinType uint32
+		index    uint32
+		path     string
+		address  string
+	}{
+		{
+			name:     "Solana",
+			coinType: CoinSolana,
+			path:     "m/44'/501'/0'/0'",
+			address:  "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk",
+		},
+		{
+			name:     "Cosmos",
+			coinType: CoinCosmos,
+			path:     "m/44'/118'/0'/0/0",
+			address:  "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4",
+		},
+		{
+			name:     "Tron",
+			coinType: CoinTron,
+			path:     "m/44'/195'/0'/0/0",
+			address:  "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Get(tt.coinType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.Derive(mnemonic, "", tt.index)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Path != tt.path {
+				t.Errorf("Derive() path = %v, want %v", got.Path, tt.path)
+			}
+			if got.Address != tt.address {
+				t.Errorf("Derive() address = %v, want %v", got.Address, tt.address)
+			}
+		})
+	}
+}
+
+func TestCosmosHRP(t *testing.T) {
+	cosmos, _ := Cosmos("cosmos").Derive(mnemonic, "", 0)
+	osmo, err := Cosmos("osmo").Derive(mnemonic, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// same key, different prefix
+	if osmo.Address[:5] != "osmo1" || string(osmo.PublicKey) != string(cosmos.PublicKey) {
+		t.Errorf("Derive() = %v", osmo.Address)
+	}
+}
+
+func TestGet(t *testing.T) {
+	if _, err := Get(0); err != ErrUnknownCoin {
+		t.Errorf("Get() error = %v, want %v", err, ErrUnknownCoin)
+	}
+	if _, err := Solana.Derive(mnemonic, "", 1<<31); err == nil {
+		t.Errorf("Derive() error = nil, want error")
+	}
+}
+
+func TestDeriveInvalidMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+	}{
+		{"empty", ""},
+		{"checksum", strings.Repeat("abandon ", 11) + "abandon"},
+		{"unknown word", strings.Replace(mnemonic, "about", "abuot", 1)},
+		{"length", strings.TrimSuffix(mnemonic, " about")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Solana.Derive(tt.mnemonic, "", 0); err != bip39.ErrInvalidMnemonic {
+				t.Errorf("Derive() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package presets
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/adesight/bip39/bip32"
+)
+
+var ed25519Secret = []byte("ed25519 seed")
+
+// slip10Ed25519 derives an ed25519 private key by SLIP-10, where every
+// index is hardened
+func slip10Ed25519(seed []byte, path string) (ed25519.PrivateKey, error) {
+	indices, err := bip32.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, ed25519Secret)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	for _, idx := range indices {
+		if idx < bip32.HardenedOffset {
+			return nil, bip32.ErrInvalidPath
+		}
+		data := append([]byte{0x00}, key...)
+		data = binary.BigEndian.AppendUint32(data, idx)
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+	return ed25519.NewKeyFromSeed(key), nil
+}
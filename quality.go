@@ -0,0 +1,224 @@
+package bip39
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// EntropyTest names an entropy quality test
+type EntropyTest uint8
+
+// EntropyTest list
+const (
+	// TestBlacklist matches entropies of published mnemonics
+	TestBlacklist EntropyTest = iota
+	// TestRepeatedByte finds long runs of one byte and repeating patterns
+	TestRepeatedByte
+	// TestMonobit compares the counts of 0 and 1 bits
+	TestMonobit
+	// TestRuns compares the number of bit runs to its expected value
+	TestRuns
+	// TestChiSquare checks the distribution of the 16 nibble values
+	TestChiSquare
+)
+
+var entropyTestNames = [...]string{
+	TestBlacklist:    "blacklist",
+	TestRepeatedByte: "repeated-byte",
+	TestMonobit:      "monobit",
+	TestRuns:         "runs",
+	TestChiSquare:    "chi-square",
+}
+
+func (t EntropyTest) String() string {
+	if int(t) < len(entropyTestNames) {
+		return entropyTestNames[t]
+	}
+	return fmt.Sprintf("EntropyTest(%d)", t)
+}
+
+// Severity tells whether a failed test rejects the entropy
+type Severity uint8
+
+// Severity list
+const (
+	// SeverityWarning is an unlikely result for random entropy, about one
+	// in ten thousand, worth logging but not rejecting
+	SeverityWarning Severity = iota
+	// SeverityError is a result random entropy practically never gives
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// EntropyQualityError describes a failed entropy quality test
+type EntropyQualityError struct {
+	Test     EntropyTest
+	Severity Severity
+	Detail   string
+}
+
+func (e *EntropyQualityError) Error() string {
+	return fmt.Sprintf("Entropy quality %v: %v test failed, %s", e.Severity, e.Test, e.Detail)
+}
+
+// Thresholds of the statistical tests, warnings are expected for about one
+// in 10^4 random entropies of 16 to 32 bytes and errors for about one in 10^9.
+// The nibble counts of short entropies are far from the chi-square
+// distribution, so the chi-square thresholds come from the exact multinomial
+// distribution: for 16 bytes P(chi >= 50) = 6.7e-5 and P(chi >= 100) = 9.4e-10,
+// longer entropies have smaller tails.
+const (
+	zWarning   = 4.0
+	zError     = 6.0
+	chiWarning = 50.0
+	chiError   = 100.0
+	runWarning = 4
+	runError   = 8
+)
+
+// CheckEntropy runs the entropy quality tests and returns the first failed
+// one as *EntropyQualityError, errors taking precedence over warnings.
+// The tests are meant to catch broken generators, like zeroed buffers or
+// repeating patterns; passing them does not prove the entropy is random.
+func CheckEntropy(entropy []byte) error {
+	entLen := len(entropy)
+	if entLen < 16 || entLen > 32 || entLen%4 != 0 {
+		return ErrEntropyLen
+	}
+
+	var warning *EntropyQualityError
+	for _, test := range []func([]byte) *EntropyQualityError{
+		checkBlacklist,
+		checkRepeatedByte,
+		checkMonobit,
+		checkRuns,
+		checkChiSquare,
+	} {
+		e := test(entropy)
+		switch {
+		case e == nil:
+		case e.Severity == SeverityError:
+			return e
+		case warning == nil:
+			warning = e
+		}
+	}
+	if warning != nil {
+		return warning
+	}
+	return nil
+}
+
+// NewMnemonicByCheckedEntropy is NewMnemonicByEntropy behind the quality
+// gate: entropy failing a test with SeverityError is rejected, warnings are
+// left to callers of CheckEntropy
+func NewMnemonicByCheckedEntropy(entropy []byte, lang Language) (string, error) {
+	if err := CheckEntropy(entropy); err != nil {
+		if e, ok := err.(*EntropyQualityError); !ok || e.Severity == SeverityError {
+			return "", err
+		}
+	}
+	return NewMnemonicByEntropy(entropy, lang)
+}
+
+func checkBlacklist(entropy []byte) *EntropyQualityError {
+	for _, weak := range weakEntropies {
+		if bytes.Equal(entropy, weak) {
+			return &EntropyQualityError{TestBlacklist, SeverityError, "entropy of a published mnemonic"}
+		}
+	}
+	return nil
+}
+
+func checkRepeatedByte(entropy []byte) *EntropyQualityError {
+	// a pattern repeated over the whole entropy, e.g. deadbeefdeadbeef...
+	for period := 1; period <= len(entropy)/2; period++ {
+		if bytes.Equal(entropy[period:], entropy[:len(entropy)-period]) {
+			return &EntropyQualityError{TestRepeatedByte, SeverityError,
+				fmt.Sprintf("pattern of %d bytes repeats", period)}
+		}
+	}
+
+	longest, run := 1, 1
+	for i := 1; i < len(entropy); i++ {
+		if entropy[i] == entropy[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return zCheck(TestRepeatedByte, float64(longest), runWarning, runError,
+		fmt.Sprintf("%d identical bytes in a row", longest))
+}
+
+func checkMonobit(entropy []byte) *EntropyQualityError {
+	n := float64(len(entropy) * 8)
+	ones := 0
+	for _, b := range entropy {
+		for ; b != 0; b &= b - 1 {
+			ones++
+		}
+	}
+	z := math.Abs(float64(ones)-n/2) / math.Sqrt(n/4)
+	return zCheck(TestMonobit, z, zWarning, zError,
+		fmt.Sprintf("%d of %d bits set, z = %.2f", ones, int(n), z))
+}
+
+func checkRuns(entropy []byte) *EntropyQualityError {
+	n := len(entropy) * 8
+	bit := func(i int) byte { return entropy[i/8] >> (7 - uint(i%8)) & 1 }
+	ones, runs := 0, 1
+	for i := 0; i < n; i++ {
+		ones += int(bit(i))
+		if i > 0 && bit(i) != bit(i-1) {
+			runs++
+		}
+	}
+	// with only one bit value the monobit test fails already
+	n1, n0, fn := float64(ones), float64(n-ones), float64(n)
+	variance := 2 * n1 * n0 * (2*n1*n0 - fn) / (fn * fn * (fn - 1))
+	if variance <= 0 {
+		return nil
+	}
+	mean := 1 + 2*n1*n0/fn
+	z := math.Abs(float64(runs)-mean) / math.Sqrt(variance)
+	return zCheck(TestRuns, z, zWarning, zError,
+		fmt.Sprintf("%d runs of bits, expected %.1f, z = %.2f", runs, mean, z))
+}
+
+func checkChiSquare(entropy []byte) *EntropyQualityError {
+	var counts [16]int
+	for _, b := range entropy {
+		counts[b>>4]++
+		counts[b&0x0f]++
+	}
+	expected := float64(len(entropy)*2) / 16
+	chi := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	return zCheck(TestChiSquare, chi, chiWarning, chiError,
+		fmt.Sprintf("nibble chi-square %.1f with 15 degrees of freedom", chi))
+}
+
+// zCheck grades a statistic against its warning and error thresholds
+func zCheck(test EntropyTest, v, warning, error float64, detail string) *EntropyQualityError {
+	switch {
+	case v >= error:
+		return &EntropyQualityError{test, SeverityError, detail}
+	case v >= warning:
+		return &EntropyQualityError{test, SeverityWarning, detail}
+	}
+	return nil
+}
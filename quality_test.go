@@ -0,0 +1,77 @@
+package bip39_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestCheckEntropy(t *testing.T) {
+	tests := []struct {
+		name     string
+		entropy  []byte
+		test     bip39.EntropyTest
+		severity bip39.Severity
+	}{
+		{"all zero", make([]byte, 16), bip39.TestBlacklist, bip39.SeverityError},
+		{"all one", bytes.Repeat([]byte{0xff}, 32), bip39.TestBlacklist, bip39.SeverityError},
+		{"published vector", mustDecodeHex("9e885d952ad362caeb4efe34a8e91bd2"), bip39.TestBlacklist, bip39.SeverityError},
+		{"zeroed buffer", bytes.Repeat([]byte{0x01}, 20), bip39.TestRepeatedByte, bip39.SeverityError},
+		{"repeating pattern", bytes.Repeat([]byte{0xde, 0xad, 0xbe, 0xef}, 4), bip39.TestRepeatedByte, bip39.SeverityError},
+		{"zero tail", append(mustDecodeHex("1c6f0b3d7e25a94f"), make([]byte, 8)...), bip39.TestRepeatedByte, bip39.SeverityError},
+		{"few bits set", mustDecodeHex("0100200008004000000240100008800104000200"), bip39.TestMonobit, bip39.SeverityError},
+		{"sparse bits", mustDecodeHex("9060528807850a45c1a8a460d022228a"), bip39.TestMonobit, bip39.SeverityWarning},
+		{"counter", mustDecodeHex("000102030405060708090a0b0c0d0e0f"), bip39.TestChiSquare, bip39.SeverityError},
+		{"alternating bits", mustDecodeHex("55aa5555aa55aaaa55aa5555aa55aa55"), bip39.TestRuns, bip39.SeverityError},
+		// chi-square 81 from the system RNG, too common at 16 bytes to reject
+		{"skewed nibbles", mustDecodeHex("ee10e5666ee48eeee78e1763ecee6062"), bip39.TestChiSquare, bip39.SeverityWarning},
+		{"two nibbles", mustDecodeHex("3cc3c33c3c3cc3c3c33cc3c33c3c3cc3"), bip39.TestChiSquare, bip39.SeverityError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bip39.CheckEntropy(tt.entropy)
+			e, ok := err.(*bip39.EntropyQualityError)
+			if !ok {
+				t.Fatalf("CheckEntropy() error = %v, want *EntropyQualityError", err)
+			}
+			if e.Test != tt.test || e.Severity != tt.severity {
+				t.Errorf("CheckEntropy() = %v, want %v %v", e, tt.severity, tt.test)
+			}
+		})
+	}
+
+	if err := bip39.CheckEntropy(make([]byte, 15)); err != bip39.ErrEntropyLen {
+		t.Errorf("CheckEntropy() error = %v, want %v", err, bip39.ErrEntropyLen)
+	}
+}
+
+func TestCheckEntropyRandom(t *testing.T) {
+	entropy := make([]byte, 32)
+	for i := 0; i < 1000; i++ {
+		if _, err := rand.Read(entropy); err != nil {
+			t.Fatal(err)
+		}
+		// warnings are expected about once in 10^4 draws
+		if e, ok := bip39.CheckEntropy(entropy).(*bip39.EntropyQualityError); ok && e.Severity == bip39.SeverityError {
+			t.Errorf("CheckEntropy(%x) = %v", entropy, e)
+		}
+	}
+}
+
+func TestNewMnemonicByCheckedEntropy(t *testing.T) {
+	// the entropy of the "abandon ... about" mnemonic
+	if _, err := bip39.NewMnemonicByCheckedEntropy(make([]byte, 16), bip39.English); err == nil {
+		t.Error("NewMnemonicByCheckedEntropy() accepted zero entropy")
+	}
+	// a warning only
+	entropy := mustDecodeHex("9060528807850a45c1a8a460d022228a")
+	got, err := bip39.NewMnemonicByCheckedEntropy(entropy, bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := bip39.NewMnemonicByEntropy(entropy, bip39.English); got != want {
+		t.Errorf("NewMnemonicByCheckedEntropy() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package bip39
+
+import (
+	"crypto/sha512"
+	"errors"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrSeedParams is returned for invalid SeedDeriver parameters
+var ErrSeedParams = errors.New("Invalid seed derivation parameters")
+
+// SeedInput selects how the mnemonic and passphrase are fed to PBKDF2
+type SeedInput uint8
+
+// SeedInput list
+const (
+	// InputMnemonic uses the mnemonic as password and the salt prefix
+	// followed by the passphrase as salt, like BIP39
+	InputMnemonic SeedInput = iota
+	// InputEntropy uses the entropy as password and the salt prefix
+	// followed by the passphrase as salt, like Substrate
+	InputEntropy
+	// InputEntropySalt uses the passphrase as password and the entropy
+	// as salt, like Cardano Icarus
+	InputEntropySalt
+)
+
+// SeedDeriver derives seeds from mnemonics with PBKDF2.
+// Zero Iterations, KeyLen and Hash fall back to the BIP39 values,
+// SaltPrefix is used as is.
+// Mnemonics are only NFKD normalized, so Electrum's own seed phrases, which
+// Electrum also lowercases and strips of accents and CJK spaces, are not
+// derived correctly with the "electrum" prefix.
+type SeedDeriver struct {
+	Input      SeedInput
+	SaltPrefix string
+	Iterations int
+	KeyLen     int
+	Hash       func() hash.Hash
+}
+
+// Seed derivation profiles, unexported so no package can weaken them
+var (
+	standardSeedDeriver  = SeedDeriver{SaltPrefix: "mnemonic", Iterations: 2048, KeyLen: 64, Hash: sha512.New}
+	icarusSeedDeriver    = SeedDeriver{Input: InputEntropySalt, Iterations: 4096, KeyLen: 96, Hash: sha512.New}
+	substrateSeedDeriver = SeedDeriver{Input: InputEntropy, SaltPrefix: "mnemonic", Iterations: 2048, KeyLen: 64, Hash: sha512.New}
+)
+
+// StandardSeedDeriver returns the BIP39 derivation used by MnemonicToSeed
+func StandardSeedDeriver() SeedDeriver {
+	return standardSeedDeriver
+}
+
+// IcarusSeedDeriver returns the derivation of the 96 bytes Cardano Icarus
+// master secret
+func IcarusSeedDeriver() SeedDeriver {
+	return icarusSeedDeriver
+}
+
+// SubstrateSeedDeriver returns the derivation of the Substrate seed, whose
+// first 32 bytes are the mini secret key
+func SubstrateSeedDeriver() SeedDeriver {
+	return substrateSeedDeriver
+}
+
+// Derive derives the seed of a mnemonic.
+// param passwd can be empty string, lang is only used by the entropy inputs
+func (d SeedDeriver) Derive(mnemonic string, passwd string, lang Language) ([]byte, error) {
+	if mnemonic == "" {
+		return nil, ErrInvalidMnemonic
+	}
+	iterations, keyLen, h := d.Iterations, d.KeyLen, d.Hash
+	if iterations == 0 {
+		iterations = standardSeedDeriver.Iterations
+	}
+	if keyLen == 0 {
+		keyLen = standardSeedDeriver.KeyLen
+	}
+	if h == nil {
+		h = standardSeedDeriver.Hash
+	}
+	if iterations < 0 || keyLen < 0 {
+		return nil, ErrSeedParams
+	}
+
+	var password, salt []byte
+	switch d.Input {
+	case InputMnemonic:
+		password = []byte(norm.NFKD.String(mnemonic))
+		salt = []byte(norm.NFKD.String(d.SaltPrefix + passwd))
+	case InputEntropy, InputEntropySalt:
+		entropy, err := MnemonicToEntropy(mnemonic, lang)
+		if err != nil {
+			return nil, err
+		}
+		password = entropy
+		salt = []byte(norm.NFKD.String(d.SaltPrefix + passwd))
+		if d.Input == InputEntropySalt {
+			password, salt = []byte(passwd), entropy
+		}
+	default:
+		return nil, ErrSeedParams
+	}
+	return pbkdf2.Key(password, salt, iterations, keyLen, h), nil
+}
@@ -0,0 +1,117 @@
+package bip39_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestSeedDeriver(t *testing.T) {
+	tests := []struct {
+		name     string
+		deriver  bip39.SeedDeriver
+		mnemonic string
+		passwd   string
+		lang     bip39.Language
+		want     string
+	}{
+		{
+			name:     "standard",
+			deriver:  bip39.StandardSeedDeriver(),
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			passwd:   "TREZOR",
+			lang:     bip39.English,
+			want:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			name:     "zero value falls back to BIP39 parameters",
+			deriver:  bip39.SeedDeriver{SaltPrefix: "mnemonic"},
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			passwd:   "TREZOR",
+			lang:     bip39.English,
+			want:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			// the first 32 bytes are the mini secret of the Substrate dev account
+			name:     "substrate",
+			deriver:  bip39.SubstrateSeedDeriver(),
+			mnemonic: "bottom drive obey lake curtain smoke basket hold race lonely fit walk",
+			lang:     bip39.English,
+			want:     "fac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.deriver.Derive(tt.mnemonic, tt.passwd, tt.lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(got, mustDecodeHex(tt.want)) {
+				t.Errorf("Derive() = %x, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeedDeriver_Icarus(t *testing.T) {
+	const mnemonic = "eight country switch draw meat scout mystery blade tip drift useless good keep usage title"
+	got, err := bip39.IcarusSeedDeriver().Derive(mnemonic, "", bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 96 {
+		t.Fatalf("Derive() length = %v, want 96", len(got))
+	}
+	// clamped as an ed25519 extended key it is the Icarus root key
+	got[0] &= 0xf8
+	got[31] &= 0x1f
+	got[31] |= 0x40
+	want := "c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620"
+	if hex.EncodeToString(got) != want {
+		t.Errorf("Derive() = %x, want %v", got, want)
+	}
+}
+
+func TestSeedDeriver_Options(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	d := bip39.SeedDeriver{SaltPrefix: "altcoin", Iterations: 4096, KeyLen: 32, Hash: sha256.New}
+	got, err := d.Derive(mnemonic, "", bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard, _ := bip39.MnemonicToSeed(mnemonic, "")
+	if len(got) != 32 || bytes.HasPrefix(standard, got) {
+		t.Errorf("Derive() = %x", got)
+	}
+
+	if _, err := bip39.IcarusSeedDeriver().Derive(mnemonic, "", bip39.French); err != bip39.ErrInvalidMnemonic {
+		t.Errorf("Derive() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+	}
+	if _, err := (bip39.SeedDeriver{Iterations: -1}).Derive(mnemonic, "", bip39.English); err != bip39.ErrSeedParams {
+		t.Errorf("Derive() error = %v, want %v", err, bip39.ErrSeedParams)
+	}
+}
+
+func TestStandardSeedDeriver_Copy(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	want, _ := bip39.MnemonicToSeed(mnemonic, "")
+	d := bip39.StandardSeedDeriver()
+	d.Iterations = 1
+	if got, _ := bip39.MnemonicToSeed(mnemonic, ""); !bytes.Equal(got, want) {
+		t.Errorf("MnemonicToSeed() = %x after changing a copy, want %x", got, want)
+	}
+	if bip39.StandardSeedDeriver().Iterations != 2048 {
+		t.Errorf("StandardSeedDeriver().Iterations = %v, want 2048", bip39.StandardSeedDeriver().Iterations)
+	}
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,185 @@
+// Package seedqr implements the SeedQR and CompactSeedQR formats used by
+// SeedSigner compatible signing devices to move English mnemonics by QR.
+//
+// A SeedQR is the numeric QR of every word index written as four decimal
+// digits. A CompactSeedQR is the byte mode QR of the raw entropy.
+package seedqr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/adesight/bip39"
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+)
+
+const (
+	digitsPerWord = 4
+	quietZone     = 4
+)
+
+// Error list
+var (
+	ErrInvalidDigits = errors.New("Invalid SeedQR digit stream")
+	ErrInvalidScale  = errors.New("Invalid QR code scale")
+)
+
+// Encode returns the SeedQR digit stream of an English mnemonic
+func Encode(mnemonic string) (string, error) {
+	if !bip39.IsMnemonicValid(mnemonic, bip39.English) {
+		return "", bip39.ErrInvalidMnemonic
+	}
+
+	// record index of word
+	wordMapping := make(map[string]int)
+	for idx, v := range bip39.English.List() {
+		wordMapping[v] = idx
+	}
+
+	var buf strings.Builder
+	for _, v := range strings.Split(mnemonic, "\x20") {
+		fmt.Fprintf(&buf, "%04d", wordMapping[v])
+	}
+	return buf.String(), nil
+}
+
+// Decode parses a SeedQR digit stream back into the mnemonic
+func Decode(digits string) (string, error) {
+	if len(digits)%digitsPerWord != 0 {
+		return "", ErrInvalidDigits
+	}
+
+	wordList := bip39.English.List()
+	words := make([]string, 0, len(digits)/digitsPerWord)
+	for i := 0; i < len(digits); i += digitsPerWord {
+		idx, err := strconv.ParseUint(digits[i:i+digitsPerWord], 10, 16)
+		if err != nil || idx >= uint64(len(wordList)) {
+			return "", ErrInvalidDigits
+		}
+		words = append(words, wordList[idx])
+	}
+
+	mnemonic := strings.Join(words, "\x20")
+	if !bip39.IsMnemonicValid(mnemonic, bip39.English) {
+		return "", bip39.ErrInvalidMnemonic
+	}
+	return mnemonic, nil
+}
+
+// EncodeCompact returns the CompactSeedQR bytes of an English mnemonic,
+// that is the entropy without the checksum bits
+func EncodeCompact(mnemonic string) ([]byte, error) {
+	return bip39.MnemonicToEntropy(mnemonic, bip39.English)
+}
+
+// DecodeCompact parses CompactSeedQR bytes back into the mnemonic
+func DecodeCompact(data []byte) (string, error) {
+	return bip39.NewMnemonicByEntropy(data, bip39.English)
+}
+
+// QRCode is a rendered SeedQR or CompactSeedQR
+type QRCode struct {
+	code barcode.Barcode
+}
+
+// NewQRCode renders mnemonic as a SeedQR, or a CompactSeedQR if compact is set
+func NewQRCode(mnemonic string, compact bool) (*QRCode, error) {
+	var code barcode.Barcode
+	if compact {
+		entropy, err := EncodeCompact(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if code, err = qr.Encode(string(entropy), qr.L, qr.Unicode); err != nil {
+			return nil, err
+		}
+	} else {
+		digits, err := Encode(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		if code, err = qr.Encode(digits, qr.L, qr.Numeric); err != nil {
+			return nil, err
+		}
+	}
+	return &QRCode{code: code}, nil
+}
+
+// Size returns the number of modules per side, without quiet zone
+func (q *QRCode) Size() int {
+	return q.code.Bounds().Dx()
+}
+
+func (q *QRCode) dark(x, y int) bool {
+	size := q.Size()
+	x, y = x-quietZone, y-quietZone
+	if x < 0 || y < 0 || x >= size || y >= size {
+		return false
+	}
+	r, g, b, _ := q.code.At(x, y).RGBA()
+	return r+g+b < 3*0x8000
+}
+
+// Image returns the code with a quiet zone, each module scale pixels wide
+func (q *QRCode) Image(scale int) (image.Image, error) {
+	if scale < 1 {
+		return nil, ErrInvalidScale
+	}
+	side := (q.Size() + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			if q.dark(x/scale, y/scale) {
+				img.SetGray(x, y, color.Gray{Y: 0x00})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 0xff})
+			}
+		}
+	}
+	return img, nil
+}
+
+// PNG returns the code as a PNG image, each module scale pixels wide
+func (q *QRCode) PNG(scale int) ([]byte, error) {
+	img, err := q.Image(scale)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// String renders the code with block characters for a terminal,
+// two rows of modules per line of text
+func (q *QRCode) String() string {
+	side := q.Size() + 2*quietZone
+	var buf strings.Builder
+	for y := 0; y < side; y += 2 {
+		for x := 0; x < side; x++ {
+			// light modules are drawn so the code reads on dark terminals
+			top, bottom := !q.dark(x, y), !q.dark(x, y+1) && y+1 < side
+			switch {
+			case top && bottom:
+				buf.WriteString("█")
+			case top:
+				buf.WriteString("▀")
+			case bottom:
+				buf.WriteString("▄")
+			default:
+				buf.WriteString(" ")
+			}
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
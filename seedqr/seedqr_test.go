@@ -0,0 +1,125 @@
+package seedqr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var testVectors = []struct {
+	name     string
+	mnemonic string
+	digits   string
+	compact  string
+	size     int
+	compSize int
+}{
+	{
+		name:     "24 words",
+		mnemonic: "attack pizza motion avocado network gather crop fresh patrol unusual wild holiday candy pony ranch winter theme error hybrid van cereal salon goddess expire",
+		digits:   "011513251154012711900771041507421289190620080870026613431420201617920614089619290300152408010643",
+		compact:  "0e74b64107f94cc0ccfae6a13dcbec3662154fec67e0e00999c07892597d190a",
+		size:     29,
+		compSize: 25,
+	},
+	{
+		name:     "12 words",
+		mnemonic: "forum undo fragile fade shy sign arrest garment culture tube off merit",
+		digits:   "073318950739065415961602009907670428187212261116",
+		compact:  "5bbd9d71a8ec7990831aff359d426545",
+		size:     25,
+		compSize: 21,
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range testVectors {
+		t.Run(tt.name, func(t *testing.T) {
+			digits, err := Encode(tt.mnemonic)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if digits != tt.digits {
+				t.Errorf("Encode() = %v, want %v", digits, tt.digits)
+			}
+			mnemonic, err := Decode(digits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mnemonic != tt.mnemonic {
+				t.Errorf("Decode() = %v, want %v", mnemonic, tt.mnemonic)
+			}
+
+			compact, err := EncodeCompact(tt.mnemonic)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(compact) != tt.compact {
+				t.Errorf("EncodeCompact() = %x, want %v", compact, tt.compact)
+			}
+			if mnemonic, err = DecodeCompact(compact); err != nil || mnemonic != tt.mnemonic {
+				t.Errorf("DecodeCompact() = %v, %v, want %v", mnemonic, err, tt.mnemonic)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		digits string
+	}{
+		{name: "length", digits: "07331895073"},
+		{name: "not a number", digits: "0733189507390654159616020099076704281872122611x6"},
+		{name: "out of range", digits: "073318950739065415961602009907670428187212262048"},
+		{name: "checksum", digits: "073318950739065415961602009907670428187212261117"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.digits); err == nil {
+				t.Errorf("Decode() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNewQRCode(t *testing.T) {
+	for _, tt := range testVectors {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NewQRCode(tt.mnemonic, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if code.Size() != tt.size {
+				t.Errorf("QRCode.Size() = %v, want %v", code.Size(), tt.size)
+			}
+
+			compact, err := NewQRCode(tt.mnemonic, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if compact.Size() != tt.compSize {
+				t.Errorf("QRCode.Size() = %v, want %v", compact.Size(), tt.compSize)
+			}
+
+			data, err := compact.PNG(2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if side := (tt.compSize + 2*quietZone) * 2; img.Bounds().Dx() != side {
+				t.Errorf("QRCode.PNG() width = %v, want %v", img.Bounds().Dx(), side)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(compact.String(), "\n"), "\n")
+			if want := (tt.compSize + 2*quietZone + 1) / 2; len(lines) != want {
+				t.Errorf("QRCode.String() has %v lines, want %v", len(lines), want)
+			}
+		})
+	}
+}
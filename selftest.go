@@ -0,0 +1,211 @@
+package bip39
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/adesight/bip39/internal/wordlist"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SelfTestError reports the first invariant a word list failed
+type SelfTestError struct {
+	Language Language
+	Reason   string
+}
+
+func (e *SelfTestError) Error() string {
+	return fmt.Sprintf("Self test failed for language %v: %s", e.Language, e.Reason)
+}
+
+// selfTestVector is a known answer test
+type selfTestVector struct {
+	lang     Language
+	entropy  string
+	mnemonic string
+	passwd   string
+	seed     string
+}
+
+var selfTestVectors = []selfTestVector{
+	// https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+	{
+		lang:     English,
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		passwd:   "TREZOR",
+		seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	// https://github.com/bip32JP/bip32JP.github.io/blob/master/test_JP_BIP39.json
+	{
+		lang:     Japanese,
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+		passwd:   "㍍ガバヴァぱばぐゞちぢ十人十色",
+		seed:     "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+	},
+	// BIP39 links no vectors for the other word lists: these take entropies
+	// of the trezor vectors and were computed with Python's hashlib from the
+	// word lists of https://github.com/bitcoin/bips/tree/master/bip-0039
+	{
+		lang:     ChineseSimplified,
+		entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		mnemonic: "蒙 台 脱 纪 构 硫 浆 霉 感 仅 鱼 汤",
+		passwd:   "TREZOR",
+		seed:     "decd71d2824a1bbadf8c3942f43504a648a8db5f1cac0ae1d0f787728353002a12644b1a6b725147c91682e7f33aec13493b9a779a7dd8ee15a5d10ab21d49e5",
+	},
+	{
+		lang:     ChineseTraditional,
+		entropy:  "c0ba5a8e914111210f2bd131f3d5e08d",
+		mnemonic: "伐 旱 泡 口 線 揭 縣 楊 斷 芳 額 件",
+		passwd:   "TREZOR",
+		seed:     "09c172005e7dd81fcd55b87d13f114207ce7726376ea74a1b9085a799b2afbd5ac5526059e722987a65f858e5301edd5f4c91deaf9d7b4f9bcc38919e5ec3725",
+	},
+	{
+		lang:     French,
+		entropy:  "6610b25967cdcca9d59875f5cb50b0ea75433311869e930b",
+		mnemonic: "fiasco ivoire mardi révulsif signal enlever envahir anormal vaisseau essayer céleste sagesse engager mener différer ruisseau lutter esprit",
+		passwd:   "TREZOR",
+		seed:     "3c0c90b30e1a8bd7aafda95f92fb09bae64988e2431d6c3896c8502f76203652f0db1d4640417d8d3f00ea4de59f1719513f1c01145eb8ee4b0fd73d4c4f706a",
+	},
+	{
+		lang:     Italian,
+		entropy:  "23db8160a31d3e0dca3688ed941adbf3",
+		mnemonic: "calmo statuto fucsia energia sodale aliante cedibile sinusoide trovare pila rinnovo tiro",
+		passwd:   "TREZOR",
+		seed:     "5d5faba1d0db08a9f0cdb602e571a9b73565707429d2482e4fcde5a9bac1728b053c65853199fbdba73716bcb8da0616820fc817a309c99607dc56dddb34c344",
+	},
+	{
+		lang:     Korean,
+		entropy:  "f30f8c1da665478f49b001d94c5fc452",
+		mnemonic: "해결 식초 거실 백성 볼펜 중세 냄새 가끔 출근 상인 한번 의심",
+		passwd:   "TREZOR",
+		seed:     "5f7125457857a8870d1ace1eb0f87479385d08ab8827998f57cb0cab5289d31a360310cdffaf4e8d1202a13fd8bba2ed9bc240a59b6d486d418647c55c7bca44",
+	},
+	{
+		lang:     Spanish,
+		entropy:  "8197a4a47f0425faeaa69deebc05ca29c0a5b5cc76ceacc0",
+		mnemonic: "llaga pudor candil yate detalle voto papá saxofón tribu talla infiel exponer altivo sonoro cifra solapa pata abuso",
+		passwd:   "TREZOR",
+		seed:     "b63a7651d8655add895fd8a45f0fd4c0c71bd8863a8e0fd72782b2f36d43ef2fa8830ab46647afc8c437e701aed41b0bc6b2df9f11887c44457aefe2c11d413d",
+	},
+}
+
+// SelfTest checks the invariants of every word list and runs the known
+// answer tests. It is cheap enough to run at program start-up
+func SelfTest() error {
+	for _, lang := range All() {
+		if err := selfTestList(lang); err != nil {
+			return err
+		}
+	}
+	for _, v := range selfTestVectors {
+		if err := v.run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func selfTestList(lang Language) error {
+	fail := func(format string, args ...interface{}) error {
+		return &SelfTestError{Language: lang, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	words := lang.List()
+	if len(words) != 2048 {
+		return fail("%d words, want 2048", len(words))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(words, "\n") + "\n"))
+	if hex.EncodeToString(sum[:]) != wordlist.SHA256[lang.String()] {
+		return fail("word list does not match the pinned sha256")
+	}
+
+	seen := make(map[string]bool, len(words))
+	prefixes := make(map[string]string, len(words))
+	for _, v := range words {
+		if seen[v] {
+			return fail("duplicate word %q", v)
+		}
+		seen[v] = true
+
+		if !norm.NFKD.IsNormalString(v) {
+			return fail("word %q is not NFKD normalized", v)
+		}
+
+		// the first 4 letters identify a word in the latin lists
+		if isLatin(lang) {
+			prefix := []rune(stripMarks(v))
+			if len(prefix) > 4 {
+				prefix = prefix[:4]
+			}
+			if other, has := prefixes[string(prefix)]; has {
+				return fail("words %q and %q share the prefix %q", other, v, string(prefix))
+			}
+			prefixes[string(prefix)] = v
+		}
+	}
+
+	// Chinese lists are ordered by frequency and Japanese by kana collation.
+	// French and Spanish ignore accents, but ñ is a letter of its own
+	if isLatin(lang) || lang == Korean {
+		key := func(s string) string { return s }
+		switch lang {
+		case French:
+			key = stripMarks
+		case Spanish:
+			key = func(s string) string {
+				return stripMarks(strings.ReplaceAll(s, "n\u0303", "n\uffff"))
+			}
+		}
+		sorted := sort.SliceIsSorted(words, func(i, j int) bool {
+			return key(words[i]) < key(words[j])
+		})
+		if !sorted {
+			return fail("word list is not sorted")
+		}
+	}
+
+	// round trip the extreme entropies
+	for _, entropy := range [][]byte{make([]byte, 16), bytes.Repeat([]byte{0xff}, 32)} {
+		mnemonic, err := NewMnemonicByEntropy(entropy, lang)
+		if err != nil {
+			return fail("%v", err)
+		}
+		got, err := MnemonicToEntropy(mnemonic, lang)
+		if err != nil || !bytes.Equal(got, entropy) {
+			return fail("entropy %x does not round trip", entropy)
+		}
+	}
+	return nil
+}
+
+func (v *selfTestVector) run() error {
+	fail := func(reason string) error {
+		return &SelfTestError{Language: v.lang, Reason: reason}
+	}
+
+	entropy, _ := hex.DecodeString(v.entropy)
+	mnemonic, err := NewMnemonicByEntropy(entropy, v.lang)
+	if err != nil || norm.NFKD.String(mnemonic) != norm.NFKD.String(v.mnemonic) {
+		return fail("mnemonic known answer test failed")
+	}
+	seed, err := MnemonicToSeed(mnemonic, v.passwd)
+	if err != nil || hex.EncodeToString(seed) != v.seed {
+		return fail("seed known answer test failed")
+	}
+	return nil
+}
+
+func isLatin(lang Language) bool {
+	switch lang {
+	case English, French, Italian, Spanish:
+		return true
+	}
+	return false
+}
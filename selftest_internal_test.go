@@ -0,0 +1,15 @@
+package bip39
+
+import "testing"
+
+func TestSelfTestVectors(t *testing.T) {
+	covered := make(map[Language]bool)
+	for _, v := range selfTestVectors {
+		covered[v.lang] = true
+	}
+	for _, lang := range All() {
+		if !covered[lang] {
+			t.Errorf("no known answer test for %v", lang)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package bip39_test
+
+import (
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := bip39.SelfTest(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSelfTestError(t *testing.T) {
+	err := &bip39.SelfTestError{Language: bip39.French, Reason: "word list is not sorted"}
+	if want := "Self test failed for language french: word list is not sorted"; err.Error() != want {
+		t.Errorf("SelfTestError.Error() = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,279 @@
+// Package substrate derives Substrate and Polkadot keys from BIP39 mnemonics.
+//
+// substrate-bip39 feeds the entropy rather than the phrase to PBKDF2, and
+// the first 32 bytes of the result are the mini secret of the sr25519 or
+// ed25519 key pair. Keys are derived along junction paths like
+// "//Alice/stash", where "//" marks a hard and "/" a soft junction.
+package substrate
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	"github.com/adesight/bip39"
+	"github.com/adesight/bip39/internal/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DevPhrase is the phrase of the well known development accounts
+const DevPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+// MiniSecretSize is the length of a mini secret key
+const MiniSecretSize = 32
+
+// Scheme is the signature scheme of a key pair
+type Scheme uint8
+
+// Scheme list
+const (
+	Sr25519 Scheme = iota
+	Ed25519
+)
+
+// Error list
+var (
+	ErrInvalidScheme   = errors.New("Invalid signature scheme")
+	ErrInvalidSeed     = errors.New("Invalid mini secret length")
+	ErrInvalidPath     = errors.New("Invalid junction path")
+	ErrSoftJunction    = errors.New("Soft junctions are not supported by ed25519")
+	ErrNoSeed          = errors.New("Key pair has no mini secret after a soft junction")
+	ErrInvalidSS58Type = errors.New("Invalid SS58 address type")
+)
+
+// MiniSecret derives the mini secret key from the entropy of a mnemonic.
+// param passwd can be empty string
+func MiniSecret(mnemonic string, passwd string, lang bip39.Language) ([]byte, error) {
+	seed, err := bip39.SubstrateSeedDeriver().Derive(mnemonic, passwd, lang)
+	if err != nil {
+		return nil, err
+	}
+	return seed[:MiniSecretSize], nil
+}
+
+// KeyPair is a sr25519 or ed25519 key pair
+type KeyPair struct {
+	scheme Scheme
+	// seed is the mini secret, unknown after a sr25519 soft junction
+	seed   []byte
+	secret *schnorrkel.SecretKey
+	public [32]byte
+}
+
+// NewKeyPair derives the key pair of a mnemonic.
+// param passwd can be empty string
+func NewKeyPair(mnemonic string, passwd string, lang bip39.Language, scheme Scheme) (*KeyPair, error) {
+	seed, err := MiniSecret(mnemonic, passwd, lang)
+	if err != nil {
+		return nil, err
+	}
+	return FromMiniSecret(seed, scheme)
+}
+
+// FromMiniSecret creates the key pair of a 32 bytes mini secret
+func FromMiniSecret(seed []byte, scheme Scheme) (*KeyPair, error) {
+	if len(seed) != MiniSecretSize {
+		return nil, ErrInvalidSeed
+	}
+	k := &KeyPair{scheme: scheme, seed: append([]byte(nil), seed...)}
+	switch scheme {
+	case Sr25519:
+		var raw [MiniSecretSize]byte
+		copy(raw[:], seed)
+		msk, err := schnorrkel.NewMiniSecretKeyFromRaw(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := k.setSecret(msk.ExpandEd25519()); err != nil {
+			return nil, err
+		}
+	case Ed25519:
+		copy(k.public[:], ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey))
+	default:
+		return nil, ErrInvalidScheme
+	}
+	return k, nil
+}
+
+func (k *KeyPair) setSecret(secret *schnorrkel.SecretKey) error {
+	pub, err := secret.Public()
+	if err != nil {
+		return err
+	}
+	k.secret = secret
+	k.public = pub.Encode()
+	return nil
+}
+
+// Scheme returns the signature scheme
+func (k *KeyPair) Scheme() Scheme {
+	return k.scheme
+}
+
+// Public returns the 32 bytes public key, which is also the account id
+func (k *KeyPair) Public() []byte {
+	return append([]byte(nil), k.public[:]...)
+}
+
+// Seed returns the mini secret, or ErrNoSeed after a soft junction
+func (k *KeyPair) Seed() ([]byte, error) {
+	if k.seed == nil {
+		return nil, ErrNoSeed
+	}
+	return append([]byte(nil), k.seed...), nil
+}
+
+// SecretKey returns the secret key: the 32 bytes scalar for sr25519,
+// the 64 bytes seed || public key for ed25519
+func (k *KeyPair) SecretKey() []byte {
+	if k.scheme == Ed25519 {
+		return ed25519.NewKeyFromSeed(k.seed)
+	}
+	enc := k.secret.Encode()
+	return enc[:]
+}
+
+// SS58Address returns the SS58 address of the public key
+func (k *KeyPair) SS58Address(network uint16) (string, error) {
+	return SS58Address(k.public[:], network)
+}
+
+// Derive derives the key pair at a junction path like "//Alice/0"
+func (k *KeyPair) Derive(path string) (*KeyPair, error) {
+	junctions, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key := k
+	for _, j := range junctions {
+		if key, err = key.derive(j); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func (k *KeyPair) derive(j Junction) (*KeyPair, error) {
+	if k.scheme == Ed25519 {
+		if !j.Hard {
+			return nil, ErrSoftJunction
+		}
+		// blake2b-256 of SCALE("Ed25519HDKD") || seed || chain code
+		h, _ := blake2b.New256(nil)
+		h.Write(scaleString("Ed25519HDKD"))
+		h.Write(k.seed)
+		h.Write(j.ChainCode[:])
+		return FromMiniSecret(h.Sum(nil), Ed25519)
+	}
+
+	if j.Hard {
+		msk, _, err := k.secret.HardDeriveMiniSecretKey(nil, j.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+		seed := msk.Encode()
+		return FromMiniSecret(seed[:], Sr25519)
+	}
+	ek, err := schnorrkel.DeriveKeySimple(k.secret, nil, j.ChainCode)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ek.Secret()
+	if err != nil {
+		return nil, err
+	}
+	child := &KeyPair{scheme: Sr25519}
+	if err := child.setSecret(secret); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
+// Junction is one step of a derivation path
+type Junction struct {
+	ChainCode [32]byte
+	Hard      bool
+}
+
+// ParsePath parses a junction path like "//polkadot//0/stash"
+func ParsePath(path string) ([]Junction, error) {
+	var junctions []Junction
+	for path != "" {
+		if path[0] != '/' {
+			return nil, ErrInvalidPath
+		}
+		var j Junction
+		path = path[1:]
+		if strings.HasPrefix(path, "/") {
+			j.Hard, path = true, path[1:]
+		}
+
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
+		}
+		code := path[:end]
+		if code == "" {
+			return nil, ErrInvalidPath
+		}
+		path = path[end:]
+
+		// numbers are encoded as u64, anything else as a SCALE string
+		var enc []byte
+		if n, err := strconv.ParseUint(code, 10, 64); err == nil {
+			enc = binary.LittleEndian.AppendUint64(nil, n)
+		} else {
+			enc = scaleString(code)
+		}
+		if len(enc) > len(j.ChainCode) {
+			sum := blake2b.Sum256(enc)
+			enc = sum[:]
+		}
+		copy(j.ChainCode[:], enc)
+		junctions = append(junctions, j)
+	}
+	return junctions, nil
+}
+
+// scaleString returns the SCALE encoding of s: compact length || bytes
+func scaleString(s string) []byte {
+	n := uint32(len(s))
+	var enc []byte
+	switch {
+	case n < 1<<6:
+		enc = []byte{byte(n << 2)}
+	case n < 1<<14:
+		enc = binary.LittleEndian.AppendUint16(nil, uint16(n<<2|0b01))
+	default:
+		enc = binary.LittleEndian.AppendUint32(nil, n<<2|0b10)
+	}
+	return append(enc, s...)
+}
+
+var ss58Prefix = []byte("SS58PRE")
+
+// SS58Address encodes a 32 bytes public key as an SS58 address of the
+// network, e.g. 0 for Polkadot or 42 for generic Substrate
+func SS58Address(pub []byte, network uint16) (string, error) {
+	var prefix []byte
+	switch {
+	case network < 64:
+		prefix = []byte{byte(network)}
+	case network < 16384:
+		prefix = []byte{
+			byte((network&0xfc)>>2) | 0x40,
+			byte(network>>8) | byte(network&0x03)<<6,
+		}
+	default:
+		return "", ErrInvalidSS58Type
+	}
+
+	data := append(prefix, pub...)
+	h, _ := blake2b.New512(nil)
+	h.Write(ss58Prefix)
+	h.Write(data)
+	return base58.Encode(append(data, h.Sum(nil)[:2]...)), nil
+}
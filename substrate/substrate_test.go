@@ -0,0 +1,147 @@
+package substrate
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestMiniSecret(t *testing.T) {
+	got, err := MiniSecret(DevPhrase, "", bip39.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "fac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e"; hex.EncodeToString(got) != want {
+		t.Errorf("MiniSecret() = %x, want %v", got, want)
+	}
+	if _, err := MiniSecret(DevPhrase, "", bip39.French); err != bip39.ErrInvalidMnemonic {
+		t.Errorf("MiniSecret() error = %v, want %v", err, bip39.ErrInvalidMnemonic)
+	}
+}
+
+func TestDerive(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme Scheme
+		path   string
+		seed   string
+		public string
+		ss58   string
+	}{
+		{
+			name:   "sr25519 //Alice",
+			scheme: Sr25519,
+			path:   "//Alice",
+			seed:   "e5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a",
+			public: "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+			ss58:   "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		},
+		{
+			name:   "ed25519 //Alice",
+			scheme: Ed25519,
+			path:   "//Alice",
+			seed:   "abf8e5bdbe30c65656c0a3cbd181ff8a56294a69dfedd27982aace4a76909115",
+			public: "88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee",
+			ss58:   "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := NewKeyPair(DevPhrase, "", bip39.English, tt.scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := root.Derive(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seed, err := key.Seed()
+			if err != nil || hex.EncodeToString(seed) != tt.seed {
+				t.Errorf("Seed() = %x, %v, want %v", seed, err, tt.seed)
+			}
+			if got := hex.EncodeToString(key.Public()); got != tt.public {
+				t.Errorf("Public() = %v, want %v", got, tt.public)
+			}
+			if got, err := key.SS58Address(42); err != nil || got != tt.ss58 {
+				t.Errorf("SS58Address() = %v, %v, want %v", got, err, tt.ss58)
+			}
+		})
+	}
+}
+
+func TestSoftJunction(t *testing.T) {
+	root, err := NewKeyPair(DevPhrase, "", bip39.English, Sr25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	soft, err := root.Derive("//Alice/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := soft.Seed(); err != ErrNoSeed {
+		t.Errorf("Seed() error = %v, want %v", err, ErrNoSeed)
+	}
+	alice, _ := root.Derive("//Alice")
+	if bytes.Equal(soft.Public(), alice.Public()) {
+		t.Errorf("soft junction did not change the key")
+	}
+	again, _ := alice.Derive("/0")
+	if !bytes.Equal(soft.Public(), again.Public()) {
+		t.Errorf("Derive() is not deterministic")
+	}
+
+	// derive_soft_known_pair of Substrate's sp-core sr25519 tests
+	known, err := root.Derive("/Alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(known.Public()), "d6c71059dbbe9ad2b0ed3f289738b800836eb425544ce694825285b958ca755e"; got != want {
+		t.Errorf("Public() = %v, want %v", got, want)
+	}
+
+	ed, _ := NewKeyPair(DevPhrase, "", bip39.English, Ed25519)
+	if _, err := ed.Derive("/soft"); err != ErrSoftJunction {
+		t.Errorf("Derive() error = %v, want %v", err, ErrSoftJunction)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path      string
+		hard      []bool
+		chainCode string
+		wantErr   bool
+	}{
+		{path: "//Alice", hard: []bool{true}, chainCode: "14416c696365"},
+		{path: "//polkadot/1", hard: []bool{true, false}, chainCode: "20706f6c6b61646f74"},
+		{path: "//2", hard: []bool{true}, chainCode: "0200000000000000"},
+		{path: "", hard: nil},
+		{path: "Alice", wantErr: true},
+		{path: "//", wantErr: true},
+		{path: "/a//", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if len(got) != len(tt.hard) {
+			t.Errorf("ParsePath(%q) = %v junctions, want %v", tt.path, len(got), len(tt.hard))
+			continue
+		}
+		for i, v := range got {
+			if v.Hard != tt.hard[i] {
+				t.Errorf("ParsePath(%q)[%v].Hard = %v", tt.path, i, v.Hard)
+			}
+		}
+		if tt.chainCode != "" {
+			want, _ := hex.DecodeString(tt.chainCode)
+			if !bytes.HasPrefix(got[0].ChainCode[:], want) {
+				t.Errorf("ParsePath(%q) chain code = %x, want %v", tt.path, got[0].ChainCode, tt.chainCode)
+			}
+		}
+	}
+}
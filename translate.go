@@ -0,0 +1,22 @@
+package bip39
+
+import "golang.org/x/text/unicode/norm"
+
+// TranslateMnemonic re-encodes the entropy of a mnemonic in another language.
+//
+// The BIP39 seed is derived from the words rather than the entropy, so the
+// translation restores a different wallet from MnemonicToSeed. seedChanged
+// reports this, and is only false when the words are unchanged.
+func TranslateMnemonic(mnemonic string, from, to Language) (translated string, seedChanged bool, err error) {
+	entropy, err := MnemonicToEntropy(mnemonic, from)
+	if err != nil {
+		return "", false, err
+	}
+	translated, err = NewMnemonicByEntropy(entropy, to)
+	if err != nil {
+		return "", false, err
+	}
+	// MnemonicToSeed normalizes, so compare like it does
+	seedChanged = norm.NFKD.String(translated) != norm.NFKD.String(mnemonic)
+	return translated, seedChanged, nil
+}
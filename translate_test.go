@@ -0,0 +1,85 @@
+package bip39_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/adesight/bip39"
+)
+
+func TestTranslateMnemonic(t *testing.T) {
+	const english = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	tests := []struct {
+		name        string
+		mnemonic    string
+		from        bip39.Language
+		to          bip39.Language
+		want        string
+		seedChanged bool
+		wantErr     bool
+	}{
+		{
+			name:        "English to Japanese",
+			mnemonic:    english,
+			from:        bip39.English,
+			to:          bip39.Japanese,
+			seedChanged: true,
+		},
+		{
+			name:        "English to Spanish",
+			mnemonic:    english,
+			from:        bip39.English,
+			to:          bip39.Spanish,
+			seedChanged: true,
+		},
+		{
+			name:        "English to English",
+			mnemonic:    english,
+			from:        bip39.English,
+			to:          bip39.English,
+			want:        english,
+			seedChanged: false,
+		},
+		{
+			name:     "invalid source",
+			mnemonic: english,
+			from:     bip39.French,
+			to:       bip39.English,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, seedChanged, err := bip39.TranslateMnemonic(tt.mnemonic, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TranslateMnemonic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("TranslateMnemonic() = %v, want %v", got, tt.want)
+			}
+			if seedChanged != tt.seedChanged {
+				t.Errorf("TranslateMnemonic() seedChanged = %v, want %v", seedChanged, tt.seedChanged)
+			}
+
+			// the entropy survives the round trip
+			want, _ := bip39.MnemonicToEntropy(tt.mnemonic, tt.from)
+			entropy, err := bip39.MnemonicToEntropy(got, tt.to)
+			if err != nil || !bytes.Equal(entropy, want) {
+				t.Errorf("MnemonicToEntropy() = %x, %v, want %x", entropy, err, want)
+			}
+			back, _, err := bip39.TranslateMnemonic(got, tt.to, tt.from)
+			if err != nil || back != tt.mnemonic {
+				t.Errorf("TranslateMnemonic() back = %v, %v, want %v", back, err, tt.mnemonic)
+			}
+
+			seed, _ := bip39.MnemonicToSeed(tt.mnemonic, "")
+			translatedSeed, _ := bip39.MnemonicToSeed(got, "")
+			if bytes.Equal(seed, translatedSeed) == tt.seedChanged {
+				t.Errorf("MnemonicToSeed() equal = %v, seedChanged %v", !tt.seedChanged, tt.seedChanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package ur
+
+import (
+	"strings"
+	"time"
+
+	"github.com/adesight/bip39"
+)
+
+// Registered types handled by this package
+const (
+	TypeBytes = "bytes"
+	TypeSeed  = "crypto-seed"
+	TypeBIP39 = "crypto-bip39"
+)
+
+// CBOR tags and map keys from BCR-2020-006
+const (
+	tagDate  = 100 // days since 1970-01-01, RFC 8943
+	tagEpoch = 1
+
+	keySeedPayload = 1
+	keySeedDate    = 2
+	keySeedName    = 3
+	keySeedNote    = 4
+
+	keyBIP39Words = 1
+	keyBIP39Lang  = 2
+)
+
+// NewBytes wraps raw data as a "bytes" UR
+func NewBytes(data []byte) UR {
+	w := new(cborWriter)
+	w.bytes(data)
+	return UR{Type: TypeBytes, CBOR: w.buf}
+}
+
+// ParseBytes returns the data of a "bytes" UR
+func ParseBytes(ur UR) ([]byte, error) {
+	if ur.Type != TypeBytes {
+		return nil, ErrInvalidType
+	}
+	r := &cborReader{data: ur.CBOR}
+	data, err := r.bytes()
+	if err != nil || !r.done() {
+		return nil, ErrInvalidCBOR
+	}
+	return data, nil
+}
+
+// Seed is the crypto-seed type
+type Seed struct {
+	Payload      []byte
+	CreationDate time.Time // optional, whole days only
+	Name         string    // optional
+	Note         string    // optional
+}
+
+// SeedFromMnemonic wraps the output of bip39.MnemonicToSeed as a crypto-seed.
+// param passwd can be empty string
+func SeedFromMnemonic(mnemonic string, passwd string) (*Seed, error) {
+	seed, err := bip39.MnemonicToSeed(mnemonic, passwd)
+	if err != nil {
+		return nil, err
+	}
+	return &Seed{Payload: seed}, nil
+}
+
+// UR encodes the seed as a crypto-seed UR
+func (s *Seed) UR() UR {
+	fields := uint64(1)
+	for _, set := range []bool{!s.CreationDate.IsZero(), s.Name != "", s.Note != ""} {
+		if set {
+			fields++
+		}
+	}
+
+	w := new(cborWriter)
+	w.head(majorMap, fields)
+	w.uint(keySeedPayload)
+	w.bytes(s.Payload)
+	if !s.CreationDate.IsZero() {
+		w.uint(keySeedDate)
+		w.head(majorTag, tagDate)
+		w.uint(uint64(s.CreationDate.Unix() / 86400))
+	}
+	if s.Name != "" {
+		w.uint(keySeedName)
+		w.text(s.Name)
+	}
+	if s.Note != "" {
+		w.uint(keySeedNote)
+		w.text(s.Note)
+	}
+	return UR{Type: TypeSeed, CBOR: w.buf}
+}
+
+// ParseSeed decodes a crypto-seed UR
+func ParseSeed(ur UR) (*Seed, error) {
+	if ur.Type != TypeSeed {
+		return nil, ErrInvalidType
+	}
+	r := &cborReader{data: ur.CBOR}
+	n, err := r.expect(majorMap)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := new(Seed)
+	for i := uint64(0); i < n; i++ {
+		key, err := r.uint()
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case keySeedPayload:
+			seed.Payload, err = r.bytes()
+		case keySeedDate:
+			seed.CreationDate, err = readDate(r)
+		case keySeedName:
+			seed.Name, err = r.text()
+		case keySeedNote:
+			seed.Note, err = r.text()
+		default:
+			err = r.skip()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(seed.Payload) == 0 || !r.done() {
+		return nil, ErrInvalidCBOR
+	}
+	return seed, nil
+}
+
+func readDate(r *cborReader) (time.Time, error) {
+	tag, err := r.expect(majorTag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	v, err := r.uint()
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch tag {
+	case tagDate:
+		return time.Unix(int64(v)*86400, 0).UTC(), nil
+	case tagEpoch:
+		return time.Unix(int64(v), 0).UTC(), nil
+	}
+	return time.Time{}, ErrInvalidCBOR
+}
+
+// NewBIP39 encodes a valid mnemonic as a crypto-bip39 UR
+func NewBIP39(mnemonic string, lang bip39.Language) (UR, error) {
+	code := lang.Code()
+	if code == "" || !bip39.IsMnemonicValid(mnemonic, lang) {
+		return UR{}, bip39.ErrInvalidMnemonic
+	}
+	words := strings.Fields(mnemonic)
+
+	w := new(cborWriter)
+	w.head(majorMap, 2)
+	w.uint(keyBIP39Words)
+	w.head(majorArray, uint64(len(words)))
+	for _, v := range words {
+		w.text(v)
+	}
+	w.uint(keyBIP39Lang)
+	w.text(code)
+	return UR{Type: TypeBIP39, CBOR: w.buf}, nil
+}
+
+// ParseBIP39 decodes a crypto-bip39 UR into a mnemonic and its language
+func ParseBIP39(ur UR) (string, bip39.Language, error) {
+	if ur.Type != TypeBIP39 {
+		return "", 0, ErrInvalidType
+	}
+	r := &cborReader{data: ur.CBOR}
+	n, err := r.expect(majorMap)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var words []string
+	lang := bip39.English
+	for i := uint64(0); i < n; i++ {
+		key, err := r.uint()
+		if err != nil {
+			return "", 0, err
+		}
+		switch key {
+		case keyBIP39Words:
+			var count uint64
+			if count, err = r.expect(majorArray); err != nil {
+				return "", 0, err
+			}
+			for ; count > 0 && err == nil; count-- {
+				var word string
+				word, err = r.text()
+				words = append(words, word)
+			}
+		case keyBIP39Lang:
+			var code string
+			if code, err = r.text(); err == nil {
+				if lang, err = bip39.ParseLanguage(code); err != nil {
+					err = ErrInvalidCBOR
+				}
+			}
+		default:
+			err = r.skip()
+		}
+		if err != nil {
+			return "", 0, err
+		}
+	}
+	if !r.done() {
+		return "", 0, ErrInvalidCBOR
+	}
+
+	mnemonic := strings.Join(words, lang.Separator())
+	if !bip39.IsMnemonicValid(mnemonic, lang) {
+		return "", 0, bip39.ErrInvalidMnemonic
+	}
+	return mnemonic, lang, nil
+}
@@ -0,0 +1,115 @@
+package ur
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"strings"
+)
+
+// Bytewords encode every byte as a four letter word. URs use the minimal
+// form, which keeps only the first and last letter of each word.
+const bytewords = "able acid also apex aqua arch atom aunt away axis back bald barn belt beta bias " +
+	"blue body brag brew bulb buzz calm cash cats chef city claw code cola cook cost " +
+	"crux curl cusp cyan dark data days deli dice diet door down draw drop drum dull " +
+	"duty each easy echo edge epic even exam exit eyes fact fair fern figs film fish " +
+	"fizz flap flew flux foxy free frog fuel fund gala game gear gems gift girl glow " +
+	"good gray grim guru gush gyro half hang hard hawk heat help high hill holy hope " +
+	"horn huts iced idea idle inch inky into iris iron item jade jazz join jolt jowl " +
+	"judo jugs jump junk jury keep keno kept keys kick kiln king kite kiwi knob lamb " +
+	"lava lazy leaf legs liar limp lion list logo loud love luau luck lung main many " +
+	"math maze memo menu meow mild mint miss monk nail navy need news next noon note " +
+	"numb obey oboe omit onyx open oval owls paid part peck play plus poem pool pose " +
+	"puff puma purr quad quiz race ramp real redo rich road rock roof ruby ruin runs " +
+	"rust safe saga scar sets silk skew slot soap solo song stub surf swan taco task " +
+	"taxi tent tied time tiny toil tomb toys trip tuna twin ugly undo unit urge user " +
+	"vast very veto vial vibe view visa void vows wall wand warm wasp wave waxy webs " +
+	"what when whiz wolf work yank yawn yell yoga yurt zaps zero zest zinc zone zoom"
+
+// ErrInvalidBytewords is returned for malformed or corrupted bytewords
+var ErrInvalidBytewords = errors.New("Invalid bytewords")
+
+var (
+	wordTable    []string
+	wordMapping  = make(map[string]byte, 256)
+	minimalTable = make(map[string]byte, 256)
+)
+
+func init() {
+	wordTable = strings.Split(bytewords, "\x20")
+	for idx, v := range wordTable {
+		wordMapping[v] = byte(idx)
+		minimalTable[v[:1]+v[3:]] = byte(idx)
+	}
+}
+
+// EncodeBytewords encodes data with its CRC32 checksum as space separated
+// bytewords
+func EncodeBytewords(data []byte) string {
+	words := make([]string, 0, len(data)+4)
+	for _, v := range appendChecksum(data) {
+		words = append(words, wordTable[v])
+	}
+	return strings.Join(words, "\x20")
+}
+
+// DecodeBytewords decodes space separated bytewords and verifies the checksum
+func DecodeBytewords(s string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(s))
+	data := make([]byte, 0, len(words))
+	for _, v := range words {
+		b, has := wordMapping[v]
+		if !has {
+			return nil, ErrInvalidBytewords
+		}
+		data = append(data, b)
+	}
+	return stripChecksum(data)
+}
+
+// EncodeMinimalBytewords encodes data with its CRC32 checksum as minimal
+// bytewords, the form used in URs
+func EncodeMinimalBytewords(data []byte) string {
+	var buf strings.Builder
+	for _, v := range appendChecksum(data) {
+		word := wordTable[v]
+		buf.WriteByte(word[0])
+		buf.WriteByte(word[3])
+	}
+	return buf.String()
+}
+
+// DecodeMinimalBytewords decodes minimal bytewords and verifies the checksum
+func DecodeMinimalBytewords(s string) ([]byte, error) {
+	s = strings.ToLower(s)
+	if len(s)%2 != 0 {
+		return nil, ErrInvalidBytewords
+	}
+	data := make([]byte, 0, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		b, has := minimalTable[s[i:i+2]]
+		if !has {
+			return nil, ErrInvalidBytewords
+		}
+		data = append(data, b)
+	}
+	return stripChecksum(data)
+}
+
+func appendChecksum(data []byte) []byte {
+	res := make([]byte, len(data)+4)
+	copy(res, data)
+	binary.BigEndian.PutUint32(res[len(data):], crc32.ChecksumIEEE(data))
+	return res
+}
+
+func stripChecksum(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, ErrInvalidBytewords
+	}
+	payload := data[:len(data)-4]
+	if binary.BigEndian.Uint32(data[len(data)-4:]) != crc32.ChecksumIEEE(payload) {
+		return nil, ErrInvalidBytewords
+	}
+	return payload, nil
+}
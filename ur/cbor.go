@@ -0,0 +1,170 @@
+package ur
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// A minimal CBOR (RFC 7049) codec covering the definite length items
+// needed by the registered types and the multipart fragments.
+
+// CBOR major types
+const (
+	majorUint  byte = 0
+	majorBytes byte = 2
+	majorText  byte = 3
+	majorArray byte = 4
+	majorMap   byte = 5
+	majorTag   byte = 6
+)
+
+// ErrInvalidCBOR is returned for CBOR which does not match the expected type
+var ErrInvalidCBOR = errors.New("Invalid CBOR")
+
+type cborWriter struct {
+	buf []byte
+}
+
+func (w *cborWriter) head(major byte, v uint64) {
+	major <<= 5
+	switch {
+	case v < 24:
+		w.buf = append(w.buf, major|byte(v))
+	case v <= 0xff:
+		w.buf = append(w.buf, major|24, byte(v))
+	case v <= 0xffff:
+		w.buf = append(w.buf, major|25, byte(v>>8), byte(v))
+	case v <= 0xffffffff:
+		w.buf = append(w.buf, major|26, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], v)
+		w.buf = append(append(w.buf, major|27), b[:]...)
+	}
+}
+
+func (w *cborWriter) uint(v uint64) {
+	w.head(majorUint, v)
+}
+
+func (w *cborWriter) bytes(b []byte) {
+	w.head(majorBytes, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *cborWriter) text(s string) {
+	w.head(majorText, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+type cborReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *cborReader) done() bool {
+	return r.pos == len(r.data)
+}
+
+func (r *cborReader) peek() (byte, error) {
+	if r.done() {
+		return 0, ErrInvalidCBOR
+	}
+	return r.data[r.pos] >> 5, nil
+}
+
+func (r *cborReader) head() (byte, uint64, error) {
+	if r.done() {
+		return 0, 0, ErrInvalidCBOR
+	}
+	major, info := r.data[r.pos]>>5, r.data[r.pos]&31
+	r.pos++
+
+	var n int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info <= 27:
+		n = 1 << (info - 24)
+	default:
+		// indefinite lengths are not needed here
+		return 0, 0, ErrInvalidCBOR
+	}
+	if len(r.data)-r.pos < n {
+		return 0, 0, ErrInvalidCBOR
+	}
+	var v uint64
+	for _, b := range r.data[r.pos : r.pos+n] {
+		v = v<<8 | uint64(b)
+	}
+	r.pos += n
+	return major, v, nil
+}
+
+func (r *cborReader) expect(major byte) (uint64, error) {
+	m, v, err := r.head()
+	if err != nil {
+		return 0, err
+	}
+	if m != major {
+		return 0, ErrInvalidCBOR
+	}
+	return v, nil
+}
+
+func (r *cborReader) uint() (uint64, error) {
+	return r.expect(majorUint)
+}
+
+func (r *cborReader) raw(major byte) ([]byte, error) {
+	n, err := r.expect(major)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(r.data)-r.pos) < n {
+		return nil, ErrInvalidCBOR
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+func (r *cborReader) bytes() ([]byte, error) {
+	b, err := r.raw(majorBytes)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+func (r *cborReader) text() (string, error) {
+	b, err := r.raw(majorText)
+	return string(b), err
+}
+
+// skip consumes one complete item of any type
+func (r *cborReader) skip() error {
+	major, v, err := r.head()
+	if err != nil {
+		return err
+	}
+	switch major {
+	case majorBytes, majorText:
+		if uint64(len(r.data)-r.pos) < v {
+			return ErrInvalidCBOR
+		}
+		r.pos += int(v)
+	case majorArray, majorMap:
+		if major == majorMap {
+			v *= 2
+		}
+		for i := uint64(0); i < v; i++ {
+			if err := r.skip(); err != nil {
+				return err
+			}
+		}
+	case majorTag:
+		return r.skip()
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package ur
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fountain codes split a message into fragments and emit an endless
+// sequence of parts. The first parts are the fragments themselves, later
+// ones XOR a pseudo-randomly chosen set of fragments, so a receiver can
+// rebuild the message from whichever parts it happens to scan.
+
+// Error list
+var (
+	ErrInvalidPart      = errors.New("Invalid multipart UR fragment")
+	ErrInconsistentPart = errors.New("Multipart UR fragment does not match the message")
+	ErrInvalidMessage   = errors.New("Invalid multipart UR message checksum")
+)
+
+// xoshiro256 is the xoshiro256** generator seeded as in the reference
+// implementation, which makes the fragment choice interoperable.
+type xoshiro256 [4]uint64
+
+func newXoshiro256(seed []byte) *xoshiro256 {
+	digest := sha256.Sum256(seed)
+	rng := new(xoshiro256)
+	for i := range rng {
+		rng[i] = binary.BigEndian.Uint64(digest[i*8:])
+	}
+	return rng
+}
+
+func (s *xoshiro256) next() uint64 {
+	res := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return res
+}
+
+func (s *xoshiro256) nextDouble() float64 {
+	return float64(s.next()) / (float64(math.MaxUint64) + 1)
+}
+
+func (s *xoshiro256) nextInt(low, high int) int {
+	return int(s.nextDouble()*float64(high-low+1)) + low
+}
+
+// sampler picks indexes with the given weights using Walker's alias method
+type sampler struct {
+	probs   []float64
+	aliases []int
+}
+
+func newSampler(weights []float64) *sampler {
+	n := len(weights)
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	p := make([]float64, n)
+	for i, w := range weights {
+		p[i] = w * float64(n) / sum
+	}
+
+	var small, large []int
+	for i := n - 1; i >= 0; i-- {
+		if p[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	s := &sampler{probs: make([]float64, n), aliases: make([]int, n)}
+	for len(small) > 0 && len(large) > 0 {
+		a, g := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+		s.probs[a] = p[a]
+		s.aliases[a] = g
+		p[g] += p[a] - 1
+		if p[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+	for _, i := range large {
+		s.probs[i] = 1
+	}
+	for _, i := range small {
+		s.probs[i] = 1
+	}
+	return s
+}
+
+func (s *sampler) next(rng *xoshiro256) int {
+	r1, r2 := rng.nextDouble(), rng.nextDouble()
+	i := int(float64(len(s.probs)) * r1)
+	if r2 < s.probs[i] {
+		return i
+	}
+	return s.aliases[i]
+}
+
+// chooseFragments returns the sorted fragment indexes mixed into part seqNum
+func chooseFragments(seqNum, seqLen int, checksum uint32) []int {
+	if seqNum <= seqLen {
+		return []int{seqNum - 1}
+	}
+
+	var seed [8]byte
+	binary.BigEndian.PutUint32(seed[:4], uint32(seqNum))
+	binary.BigEndian.PutUint32(seed[4:], checksum)
+	rng := newXoshiro256(seed[:])
+
+	weights := make([]float64, seqLen)
+	for i := range weights {
+		weights[i] = 1 / float64(i+1)
+	}
+	degree := newSampler(weights).next(rng) + 1
+
+	remaining := make([]int, seqLen)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	indexes := make([]int, 0, degree)
+	for len(indexes) < degree {
+		k := rng.nextInt(0, len(remaining)-1)
+		indexes = append(indexes, remaining[k])
+		remaining = append(remaining[:k], remaining[k+1:]...)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// fragmentLength finds the fragment length which splits a message of
+// length n into the fewest fragments no longer than maxLen
+func fragmentLength(n, minLen, maxLen int) int {
+	maxCount := n / minLen
+	if maxCount < 1 {
+		maxCount = 1
+	}
+	var fragLen int
+	for count := 1; count <= maxCount; count++ {
+		fragLen = (n + count - 1) / count
+		if fragLen <= maxLen {
+			break
+		}
+	}
+	return fragLen
+}
+
+// maxMessageLen bounds multipart messages, far above the few hundred bytes
+// of seeds and PSBTs shown as animated QR codes
+const maxMessageLen = 64 * 1024
+
+// part is one emitted or received fountain part
+type part struct {
+	seqNum   int
+	seqLen   int
+	msgLen   int
+	checksum uint32
+	data     []byte
+}
+
+func (p *part) marshal() []byte {
+	w := new(cborWriter)
+	w.head(majorArray, 5)
+	w.uint(uint64(p.seqNum))
+	w.uint(uint64(p.seqLen))
+	w.uint(uint64(p.msgLen))
+	w.uint(uint64(p.checksum))
+	w.bytes(p.data)
+	return w.buf
+}
+
+func unmarshalPart(data []byte) (*part, error) {
+	r := &cborReader{data: data}
+	if n, err := r.expect(majorArray); err != nil || n != 5 {
+		return nil, ErrInvalidPart
+	}
+	var fields [4]uint64
+	for i := range fields {
+		v, err := r.uint()
+		if err != nil || v > math.MaxUint32 {
+			return nil, ErrInvalidPart
+		}
+		fields[i] = v
+	}
+	frag, err := r.bytes()
+	if err != nil || !r.done() {
+		return nil, ErrInvalidPart
+	}
+	p := &part{
+		seqNum:   int(fields[0]),
+		seqLen:   int(fields[1]),
+		msgLen:   int(fields[2]),
+		checksum: uint32(fields[3]),
+		data:     frag,
+	}
+	// bounding seqLen by msgLen keeps chooseFragments small for crafted parts
+	if p.seqNum < 1 || p.seqLen < 1 || p.msgLen < 1 || p.msgLen > maxMessageLen ||
+		p.seqLen > p.msgLen || len(p.data) > p.msgLen || len(p.data)*p.seqLen < p.msgLen {
+		return nil, ErrInvalidPart
+	}
+	return p, nil
+}
+
+// fountainEncoder emits the parts of a message
+type fountainEncoder struct {
+	msgLen    int
+	checksum  uint32
+	fragments [][]byte
+	seqNum    int
+}
+
+func newFountainEncoder(msg []byte, maxFragmentLen int) *fountainEncoder {
+	const minFragmentLen = 10
+	fragLen := fragmentLength(len(msg), minFragmentLen, maxFragmentLen)
+	enc := &fountainEncoder{
+		msgLen:   len(msg),
+		checksum: crc32.ChecksumIEEE(msg),
+	}
+	for i := 0; i < len(msg); i += fragLen {
+		frag := make([]byte, fragLen)
+		copy(frag, msg[i:])
+		enc.fragments = append(enc.fragments, frag)
+	}
+	return enc
+}
+
+func (e *fountainEncoder) nextPart() *part {
+	e.seqNum++
+	seqLen := len(e.fragments)
+	data := make([]byte, len(e.fragments[0]))
+	for _, i := range chooseFragments(e.seqNum, seqLen, e.checksum) {
+		xorInto(data, e.fragments[i])
+	}
+	return &part{
+		seqNum:   e.seqNum,
+		seqLen:   seqLen,
+		msgLen:   e.msgLen,
+		checksum: e.checksum,
+		data:     data,
+	}
+}
+
+// mixedPart is a received part reduced to the fragments it still mixes
+type mixedPart struct {
+	indexes []int
+	data    []byte
+}
+
+func (m *mixedPart) key() string {
+	var buf strings.Builder
+	for _, i := range m.indexes {
+		buf.WriteString(strconv.Itoa(i))
+		buf.WriteByte(',')
+	}
+	return buf.String()
+}
+
+// reduce removes the fragments of b from m when b is a subset of m
+func (m *mixedPart) reduce(b *mixedPart) *mixedPart {
+	rest := make([]int, 0, len(m.indexes))
+	j := 0
+	for _, i := range m.indexes {
+		if j < len(b.indexes) && b.indexes[j] == i {
+			j++
+			continue
+		}
+		rest = append(rest, i)
+	}
+	if j != len(b.indexes) {
+		return m
+	}
+	data := append([]byte(nil), m.data...)
+	xorInto(data, b.data)
+	return &mixedPart{indexes: rest, data: data}
+}
+
+// fountainDecoder rebuilds a message from parts received in any order
+type fountainDecoder struct {
+	first  *part
+	simple map[int]*mixedPart
+	mixed  map[string]*mixedPart
+	queue  []*mixedPart
+	result []byte
+	err    error
+}
+
+func newFountainDecoder() *fountainDecoder {
+	return &fountainDecoder{
+		simple: make(map[int]*mixedPart),
+		mixed:  make(map[string]*mixedPart),
+	}
+}
+
+func (d *fountainDecoder) complete() bool {
+	return d.result != nil || d.err != nil
+}
+
+func (d *fountainDecoder) receive(p *part) error {
+	if d.complete() {
+		// a failed checksum is final, keep reporting it
+		return d.err
+	}
+	if d.first == nil {
+		d.first = p
+	} else if p.seqLen != d.first.seqLen || p.msgLen != d.first.msgLen ||
+		p.checksum != d.first.checksum || len(p.data) != len(d.first.data) {
+		return ErrInconsistentPart
+	}
+
+	d.queue = append(d.queue, &mixedPart{
+		indexes: chooseFragments(p.seqNum, p.seqLen, p.checksum),
+		data:    p.data,
+	})
+	for len(d.queue) > 0 && !d.complete() {
+		m := d.queue[0]
+		d.queue = d.queue[1:]
+		if len(m.indexes) == 1 {
+			d.processSimple(m)
+		} else {
+			d.processMixed(m)
+		}
+	}
+	return d.err
+}
+
+func (d *fountainDecoder) processSimple(m *mixedPart) {
+	index := m.indexes[0]
+	if _, has := d.simple[index]; has {
+		return
+	}
+	d.simple[index] = m
+
+	if len(d.simple) == d.first.seqLen {
+		msg := make([]byte, 0, d.first.seqLen*len(m.data))
+		for i := 0; i < d.first.seqLen; i++ {
+			msg = append(msg, d.simple[i].data...)
+		}
+		msg = msg[:d.first.msgLen]
+		if crc32.ChecksumIEEE(msg) != d.first.checksum {
+			d.err = ErrInvalidMessage
+			return
+		}
+		d.result = msg
+		return
+	}
+	d.reduceMixedBy(m)
+}
+
+func (d *fountainDecoder) processMixed(m *mixedPart) {
+	if _, has := d.mixed[m.key()]; has {
+		return
+	}
+	for _, s := range d.simple {
+		m = m.reduce(s)
+	}
+	for _, x := range d.mixed {
+		m = m.reduce(x)
+	}
+	switch len(m.indexes) {
+	case 0:
+		// nothing new in this part
+	case 1:
+		d.queue = append(d.queue, m)
+	default:
+		d.reduceMixedBy(m)
+		d.mixed[m.key()] = m
+	}
+}
+
+func (d *fountainDecoder) reduceMixedBy(b *mixedPart) {
+	mixed := make(map[string]*mixedPart, len(d.mixed))
+	for _, m := range d.mixed {
+		r := m.reduce(b)
+		if len(r.indexes) == 1 {
+			d.queue = append(d.queue, r)
+		} else if len(r.indexes) > 1 {
+			mixed[r.key()] = r
+		}
+	}
+	d.mixed = mixed
+}
+
+func xorInto(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
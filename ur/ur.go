@@ -0,0 +1,213 @@
+// Package ur implements Blockchain Commons Uniform Resources (BCR-2020-005)
+// for BIP39 mnemonics and seeds.
+//
+// A UR is a typed CBOR payload written as minimal bytewords, for example
+// "ur:crypto-seed/...". Payloads too long for a single QR code are split
+// with fountain codes into a multipart sequence, "ur:type/seqNum-seqLen/...",
+// meant to be shown as an animated QR code.
+package ur
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const scheme = "ur:"
+
+// Error list
+var (
+	ErrInvalidUR   = errors.New("Invalid UR")
+	ErrInvalidType = errors.New("Invalid UR type")
+	ErrIncomplete  = errors.New("Multipart UR is not complete")
+)
+
+// UR is a Uniform Resource, a CBOR payload tagged with its registered type
+type UR struct {
+	Type string
+	CBOR []byte
+}
+
+func isValidType(t string) bool {
+	if t == "" {
+		return false
+	}
+	for _, c := range t {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode returns the single part form of ur
+func Encode(ur UR) (string, error) {
+	if !isValidType(ur.Type) {
+		return "", ErrInvalidType
+	}
+	return scheme + ur.Type + "/" + EncodeMinimalBytewords(ur.CBOR), nil
+}
+
+// Decode parses a single part UR
+func Decode(s string) (UR, error) {
+	t, seq, body, err := split(s)
+	if err != nil {
+		return UR{}, err
+	}
+	if seq != "" {
+		return UR{}, ErrInvalidUR
+	}
+	payload, err := DecodeMinimalBytewords(body)
+	if err != nil {
+		return UR{}, err
+	}
+	return UR{Type: t, CBOR: payload}, nil
+}
+
+// split breaks a UR into its type, optional sequence and body.
+// URs are case-insensitive so that they fit QR alphanumeric mode.
+func split(s string) (string, string, string, error) {
+	s = strings.ToLower(s)
+	if !strings.HasPrefix(s, scheme) {
+		return "", "", "", ErrInvalidUR
+	}
+	fields := strings.Split(s[len(scheme):], "/")
+	if !isValidType(fields[0]) {
+		return "", "", "", ErrInvalidType
+	}
+	switch len(fields) {
+	case 2:
+		return fields[0], "", fields[1], nil
+	case 3:
+		return fields[0], fields[1], fields[2], nil
+	}
+	return "", "", "", ErrInvalidUR
+}
+
+// Encoder emits the parts of a UR, cycling through fountain coded
+// fragments once the plain fragments have all been sent
+type Encoder struct {
+	ur       UR
+	fountain *fountainEncoder
+}
+
+// NewEncoder creates an encoder whose fragments are at most maxFragmentLen bytes.
+// Payloads are limited to 64 KiB, the limit of Decoder.
+func NewEncoder(ur UR, maxFragmentLen int) (*Encoder, error) {
+	if !isValidType(ur.Type) {
+		return nil, ErrInvalidType
+	}
+	if len(ur.CBOR) == 0 || len(ur.CBOR) > maxMessageLen || maxFragmentLen < 10 {
+		return nil, ErrInvalidUR
+	}
+	return &Encoder{ur: ur, fountain: newFountainEncoder(ur.CBOR, maxFragmentLen)}, nil
+}
+
+// SeqLen returns the number of fragments the payload is split into
+func (e *Encoder) SeqLen() int {
+	return len(e.fountain.fragments)
+}
+
+// IsSinglePart reports whether the UR fits a single part
+func (e *Encoder) IsSinglePart() bool {
+	return e.SeqLen() == 1
+}
+
+// NextPart returns the next part to display.
+// A single part UR is returned unchanged on every call.
+func (e *Encoder) NextPart() string {
+	if e.IsSinglePart() {
+		// the type was validated by NewEncoder
+		s, _ := Encode(e.ur)
+		return s
+	}
+	p := e.fountain.nextPart()
+	return scheme + e.ur.Type + "/" + strconv.Itoa(p.seqNum) + "-" + strconv.Itoa(p.seqLen) +
+		"/" + EncodeMinimalBytewords(p.marshal())
+}
+
+// Decoder collects the parts of a UR in any order
+type Decoder struct {
+	urType   string
+	fountain *fountainDecoder
+	result   *UR
+}
+
+// NewDecoder creates an empty decoder
+func NewDecoder() *Decoder {
+	return &Decoder{fountain: newFountainDecoder()}
+}
+
+// Receive feeds one scanned part to the decoder.
+// Once the parts combine into a message failing its checksum, every call
+// returns ErrInvalidMessage and a new Decoder is needed.
+func (d *Decoder) Receive(s string) error {
+	if d.IsComplete() {
+		return nil
+	}
+	if d.fountain.err != nil {
+		return d.fountain.err
+	}
+	t, seq, body, err := split(s)
+	if err != nil {
+		return err
+	}
+	if d.urType != "" && t != d.urType {
+		return ErrInvalidType
+	}
+
+	if seq == "" {
+		payload, err := DecodeMinimalBytewords(body)
+		if err != nil {
+			return err
+		}
+		d.result = &UR{Type: t, CBOR: payload}
+		return nil
+	}
+
+	nums := strings.Split(seq, "-")
+	if len(nums) != 2 {
+		return ErrInvalidPart
+	}
+	seqNum, err1 := strconv.Atoi(nums[0])
+	seqLen, err2 := strconv.Atoi(nums[1])
+	if err1 != nil || err2 != nil {
+		return ErrInvalidPart
+	}
+	data, err := DecodeMinimalBytewords(body)
+	if err != nil {
+		return err
+	}
+	p, err := unmarshalPart(data)
+	if err != nil {
+		return err
+	}
+	if p.seqNum != seqNum || p.seqLen != seqLen {
+		return ErrInvalidPart
+	}
+
+	d.urType = t
+	if err := d.fountain.receive(p); err != nil {
+		return err
+	}
+	if d.fountain.result != nil {
+		d.result = &UR{Type: t, CBOR: d.fountain.result}
+	}
+	return nil
+}
+
+// IsComplete reports whether the UR has been fully received
+func (d *Decoder) IsComplete() bool {
+	return d.result != nil
+}
+
+// Result returns the received UR
+func (d *Decoder) Result() (UR, error) {
+	if d.fountain.err != nil {
+		return UR{}, d.fountain.err
+	}
+	if d.result == nil {
+		return UR{}, ErrIncomplete
+	}
+	return *d.result, nil
+}
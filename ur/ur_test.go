@@ -0,0 +1,251 @@
+package ur
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/adesight/bip39"
+)
+
+func TestBytewords(t *testing.T) {
+	data := []byte{0, 1, 2, 128, 255}
+	if got := EncodeBytewords(data); got != "able acid also lava zoom jade need echo taxi" {
+		t.Errorf("EncodeBytewords() = %v", got)
+	}
+	if got := EncodeMinimalBytewords(data); got != "aeadaolazmjendeoti" {
+		t.Errorf("EncodeMinimalBytewords() = %v", got)
+	}
+	if got, err := DecodeBytewords("ABLE acid also lava zoom jade need echo taxi"); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("DecodeBytewords() = %x, %v", got, err)
+	}
+	if got, err := DecodeMinimalBytewords("aeadaolazmjendeoti"); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("DecodeMinimalBytewords() = %x, %v", got, err)
+	}
+	if _, err := DecodeMinimalBytewords("aeadaolazmjendeota"); err != ErrInvalidBytewords {
+		t.Errorf("DecodeMinimalBytewords() error = %v, want %v", err, ErrInvalidBytewords)
+	}
+}
+
+func TestXoshiro256(t *testing.T) {
+	rng := newXoshiro256([]byte("Wolf"))
+	want := []uint64{42, 81, 85, 8, 82, 84, 76, 73, 70, 88, 2, 74, 40, 48, 77, 54, 88, 7, 5, 88}
+	for i, v := range want {
+		if got := rng.next() % 100; got != v {
+			t.Fatalf("xoshiro256.next() #%v = %v, want %v", i, got, v)
+		}
+	}
+}
+
+func makeMessage(n int, seed string) []byte {
+	rng := newXoshiro256([]byte(seed))
+	msg := make([]byte, n)
+	for i := range msg {
+		msg[i] = byte(rng.nextInt(0, 255))
+	}
+	return msg
+}
+
+func TestChooseFragments(t *testing.T) {
+	msg := makeMessage(1024, "Wolf")
+	enc := newFountainEncoder(msg, 100)
+	if len(enc.fragments) != 11 {
+		t.Fatalf("fragments = %v, want 11", len(enc.fragments))
+	}
+	want := [][]int{
+		{0}, {1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10},
+		{9}, {2, 5, 6, 8, 9, 10}, {8}, {1, 5}, {1}, {0, 2, 4, 5, 8, 10}, {5}, {2}, {2},
+	}
+	for i, v := range want {
+		if got := chooseFragments(i+1, 11, enc.checksum); !reflect.DeepEqual(got, v) {
+			t.Errorf("chooseFragments(%v) = %v, want %v", i+1, got, v)
+		}
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	msg := makeMessage(32767, "Wolf")
+	ur := NewBytes(msg)
+	enc, err := NewEncoder(ur, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc.IsSinglePart() {
+		t.Fatal("Encoder.IsSinglePart() = true, want false")
+	}
+
+	dec := NewDecoder()
+	for i := 0; !dec.IsComplete(); i++ {
+		part := enc.NextPart()
+		// drop every other part to exercise the fountain code
+		if i%2 == 0 {
+			continue
+		}
+		if err := dec.Receive(part); err != nil {
+			t.Fatal(err)
+		}
+		if i > 10*enc.SeqLen() {
+			t.Fatal("Decoder did not complete")
+		}
+	}
+	got, err := dec.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ParseBytes(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, msg) {
+		t.Errorf("Decoder.Result() does not match the message")
+	}
+}
+
+func TestDecoderInvalidPart(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  string
+		p    part
+	}{
+		{"sequence length", "4294967295-4294967294", part{seqNum: 4294967295, seqLen: 4294967294, msgLen: 100, data: make([]byte, 10)}},
+		{"more fragments than bytes", "11-101", part{seqNum: 11, seqLen: 101, msgLen: 100, data: make([]byte, 1)}},
+		{"message length", "1-2000", part{seqNum: 1, seqLen: 2000, msgLen: maxMessageLen + 1, data: make([]byte, 40)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := "ur:bytes/" + tt.seq + "/" + EncodeMinimalBytewords(tt.p.marshal())
+			if err := NewDecoder().Receive(s); err != ErrInvalidPart {
+				t.Errorf("Decoder.Receive() error = %v, want %v", err, ErrInvalidPart)
+			}
+		})
+	}
+}
+
+func TestDecoderCorruptedPart(t *testing.T) {
+	enc, err := NewEncoder(NewBytes(makeMessage(100, "Wolf")), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := NewDecoder()
+	var last error
+	for i := 0; i < enc.SeqLen(); i++ {
+		p := enc.fountain.nextPart()
+		if i == 0 {
+			p.data[0] ^= 0xff
+		}
+		s := "ur:bytes/" + strconv.Itoa(p.seqNum) + "-" + strconv.Itoa(p.seqLen) + "/" + EncodeMinimalBytewords(p.marshal())
+		last = dec.Receive(s)
+	}
+	if last != ErrInvalidMessage {
+		t.Fatalf("Decoder.Receive() error = %v, want %v", last, ErrInvalidMessage)
+	}
+	// the decoder must not swallow later parts silently
+	if err := dec.Receive(enc.NextPart()); err != ErrInvalidMessage {
+		t.Errorf("Decoder.Receive() error = %v, want %v", err, ErrInvalidMessage)
+	}
+	if _, err := dec.Result(); err != ErrInvalidMessage {
+		t.Errorf("Decoder.Result() error = %v, want %v", err, ErrInvalidMessage)
+	}
+}
+
+func TestSinglePart(t *testing.T) {
+	ur := NewBytes([]byte{0, 1, 2, 128, 255})
+	enc, err := NewEncoder(ur, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := enc.NextPart()
+	if !enc.IsSinglePart() || s != "ur:bytes/feaeadaolazmfxwyzepa" {
+		t.Errorf("Encoder.NextPart() = %v", s)
+	}
+	got, err := Decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, ur) {
+		t.Errorf("Decode() = %v, want %v", got, ur)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	payload, _ := hex.DecodeString("c7098580125e2ab0981253468b2dbc52")
+	seed := &Seed{
+		Payload:      payload,
+		CreationDate: time.Date(2021, 2, 24, 0, 0, 0, 0, time.UTC),
+	}
+	ur := seed.UR()
+	if want := "a20150c7098580125e2ab0981253468b2dbc5202d8641948fa"; hex.EncodeToString(ur.CBOR) != want {
+		t.Errorf("Seed.UR() = %x, want %v", ur.CBOR, want)
+	}
+	got, err := ParseSeed(ur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, seed) {
+		t.Errorf("ParseSeed() = %+v, want %+v", got, seed)
+	}
+
+	fromMnemonic, err := SeedFromMnemonic("moment butter trigger coffee divert choose slim tiger ice series cup enough", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "4b8c14466dbad77f6ff3adf016d372fbccfb0308ea5a36c9ab0c6f6eb1162ca461c02a1df2a1b854291785e59f0d98eb39af4d02a0ca8ffae5f66ff2dd0e2a48"; hex.EncodeToString(fromMnemonic.Payload) != want {
+		t.Errorf("SeedFromMnemonic() = %x, want %v", fromMnemonic.Payload, want)
+	}
+}
+
+func TestBIP39(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		lang     bip39.Language
+		cbor     string
+	}{
+		{
+			name:     "English",
+			mnemonic: "shield group erode awake lock sausage cash glare wave crew flame glove",
+			lang:     bip39.English,
+			cbor: "a2018c66736869656c646567726f75706565726f6465656177616b65646c6f636b6773617573616765646361736865676c617265647761766564637265776566" +
+				"6c616d6565676c6f76650262656e",
+		},
+		{
+			name:     "Japanese",
+			mnemonic: "ねほりはほり　ひらがな　とさか　そつう　おうじ　あてな　きくらげ　みもと　してつ　ぱそこん　にってい　いこつ",
+			lang:     bip39.Japanese,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur, err := NewBIP39(tt.mnemonic, tt.lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.cbor != "" && hex.EncodeToString(ur.CBOR) != tt.cbor {
+				t.Errorf("NewBIP39() = %x, want %v", ur.CBOR, tt.cbor)
+			}
+
+			s, err := Encode(ur)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Decode(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mnemonic, lang, err := ParseBIP39(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mnemonic != tt.mnemonic || lang != tt.lang {
+				t.Errorf("ParseBIP39() = %v, %v, want %v, %v", mnemonic, lang, tt.mnemonic, tt.lang)
+			}
+		})
+	}
+
+	if _, err := NewBIP39("shield group erode awake lock sausage cash glare wave crew flame flame", bip39.English); err == nil {
+		t.Errorf("NewBIP39() error = nil, want error")
+	}
+}
@@ -0,0 +1,57 @@
+package bip39
+
+import "encoding/hex"
+
+// weakEntropies are entropies of mnemonics published as test vectors: the
+// BIP39 reference vectors of python-mnemonic, then the additional vectors of
+// bip32JP. The patterned ones are also caught by the repeated-byte test.
+var weakEntropies = mustDecodeHexList(
+	"00000000000000000000000000000000",
+	"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+	"80808080808080808080808080808080",
+	"ffffffffffffffffffffffffffffffff",
+	"000000000000000000000000000000000000000000000000",
+	"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+	"808080808080808080808080808080808080808080808080",
+	"ffffffffffffffffffffffffffffffffffffffffffffffff",
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+	"8080808080808080808080808080808080808080808080808080808080808080",
+	"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"9e885d952ad362caeb4efe34a8e91bd2",
+	"6610b25967cdcca9d59875f5cb50b0ea75433311869e930b",
+	"68a79eaca2324873eacc50cb9c6eca8cc68ea5d936f98787c60c7ebc74e6ce7c",
+	"c0ba5a8e914111210f2bd131f3d5e08d",
+	"6d9be1ee6ebd27a258115aad99b7317b9c8d28b6d76431c3",
+	"9f6a2878b2520799a44ef18bc7df394e7061a224d2c33cd015b157d746869863",
+	"23db8160a31d3e0dca3688ed941adbf3",
+	"8197a4a47f0425faeaa69deebc05ca29c0a5b5cc76ceacc0",
+	"066dca1a2bb7e8a1db2832148ce9933eea0f3ac9548d793112d9a95c9407efad",
+	"f30f8c1da665478f49b001d94c5fc452",
+	"c10ec20dc3cd9f652c7fac2f1230f7a3c828389a14392f05",
+	"f585c11aec520db57dd353c69554b21a89b20fb0650966fa0a9d6f74fd989d8f",
+	"77c2b00716cec7213839159e404db50d",
+	"b63a9c59a6e641f288ebc103017f1da9f8290b3da6bdef7b",
+	"3e141609b97933b66a060dcddc71fad1d91677db872031e85f4c015c5e7e8982",
+	"0460ef47585604c5660618db2e6a7e7f",
+	"72f60ebac5dd8add8d2a25a797102c3ce21bc029c200076f",
+	"2c85efc7f24ee4573d2b81a6ec66cee209b2dcbd09d8eddc51e0215b0b68e416",
+	"eaebabb2383351fd31d703840b32e9e2",
+	"7ac45cfe7722ee6c7ba84fbc2d5bd61b45cb2fe5eb65aa78",
+	"4fa1a8bc3e6d80ee1316050e862c1812031493212b7ec3f3bb1b08f168cabeef",
+	"18ab19a9f54a9274f03e5209a2ac8a91",
+	"18a2e1d81b8ecfb2a333adcb0c17a5b9eb76cc5d05db91a4",
+	"15da872c95a13dd738fbf50e427583ad61f18fd99f628c417a61cf8343c90419",
+)
+
+func mustDecodeHexList(list ...string) [][]byte {
+	out := make([][]byte, len(list))
+	for i, s := range list {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			panic(err)
+		}
+		out[i] = b
+	}
+	return out
+}
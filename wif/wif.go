@@ -0,0 +1,119 @@
+// Package wif implements the Wallet Import Format of single secp256k1
+// private keys, as used by legacy wallets and Bitcoin Core's importprivkey.
+package wif
+
+import (
+	"errors"
+
+	"github.com/adesight/bip39/bip32"
+	"github.com/adesight/bip39/internal/base58"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// Version bytes of the format
+const (
+	MainnetVersion byte = 0x80
+	TestnetVersion byte = 0xef
+)
+
+const compressedFlag = 0x01
+
+// Error list
+var (
+	ErrInvalidLength     = errors.New("Invalid WIF length")
+	ErrInvalidVersion    = errors.New("Invalid WIF version")
+	ErrInvalidFlag       = errors.New("Invalid WIF compression flag")
+	ErrInvalidPrivateKey = errors.New("Invalid private key")
+)
+
+// WIF is a decoded Wallet Import Format key
+type WIF struct {
+	PrivateKey []byte
+	Compressed bool // the key is used with its compressed public key
+	Testnet    bool
+}
+
+// Encode encodes a 32 bytes private key
+func Encode(privateKey []byte, compressed, testnet bool) (string, error) {
+	if !validKey(privateKey) {
+		return "", ErrInvalidPrivateKey
+	}
+	w := WIF{PrivateKey: privateKey, Compressed: compressed, Testnet: testnet}
+	return w.String(), nil
+}
+
+// FromKey encodes the private key of a BIP32 key as compressed WIF, on the
+// network of the key's version
+func FromKey(k *bip32.Key) (string, error) {
+	if !k.IsPrivate() {
+		return "", ErrInvalidPrivateKey
+	}
+	return Encode(k.PrivateKey(), true, k.Version() == bip32.TestnetPrivate)
+}
+
+// Decode decodes a WIF string, rejecting bad checksums, unknown versions,
+// compression flags other than 0x01 and keys out of the curve order
+func Decode(s string) (*WIF, error) {
+	data, err := base58.CheckDecode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WIF{}
+	switch len(data) {
+	case 1 + 32:
+	case 1 + 32 + 1:
+		if data[33] != compressedFlag {
+			return nil, ErrInvalidFlag
+		}
+		w.Compressed = true
+	default:
+		return nil, ErrInvalidLength
+	}
+
+	switch data[0] {
+	case MainnetVersion:
+	case TestnetVersion:
+		w.Testnet = true
+	default:
+		return nil, ErrInvalidVersion
+	}
+
+	w.PrivateKey = data[1:33]
+	if !validKey(w.PrivateKey) {
+		return nil, ErrInvalidPrivateKey
+	}
+	return w, nil
+}
+
+// String returns the base58check encoding of the key
+func (w *WIF) String() string {
+	version := MainnetVersion
+	if w.Testnet {
+		version = TestnetVersion
+	}
+	payload := append([]byte{version}, w.PrivateKey...)
+	if w.Compressed {
+		payload = append(payload, compressedFlag)
+	}
+	return base58.CheckEncode(payload)
+}
+
+// PublicKey returns the public key in the form selected by Compressed
+func (w *WIF) PublicKey() []byte {
+	pub := secp256k1.PrivKeyFromBytes(w.PrivateKey).PubKey()
+	if w.Compressed {
+		return pub.SerializeCompressed()
+	}
+	return pub.SerializeUncompressed()
+}
+
+// validKey reports whether key is 32 bytes in [1, n-1]
+func validKey(key []byte) bool {
+	if len(key) != 32 {
+		return false
+	}
+	var k secp256k1.ModNScalar
+	overflow := k.SetByteSlice(key)
+	return !overflow && !k.IsZero()
+}
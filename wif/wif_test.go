@@ -0,0 +1,110 @@
+package wif
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/adesight/bip39/bip32"
+	"github.com/adesight/bip39/internal/base58"
+)
+
+var testKey, _ = hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name       string
+		compressed bool
+		testnet    bool
+		want       string
+	}{
+		{"uncompressed", false, false, "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+		{"compressed", true, false, "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"},
+		{"testnet uncompressed", false, true, "91gGn1HgSap6CbU12F6z3pJri26xzp7Ay1VW6NHCoEayNXwRpu2"},
+		{"testnet compressed", true, true, "cMzLdeGd5vEqxB8B6VFQoRopQ3sLAAvEzDAoQgvX54xwofSWj1fx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encode(testKey, tt.compressed, tt.testnet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode() = %v, want %v", got, tt.want)
+			}
+
+			w, err := Decode(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(w.PrivateKey, testKey) || w.Compressed != tt.compressed || w.Testnet != tt.testnet {
+				t.Errorf("Decode() = %+v", w)
+			}
+			if w.String() != got {
+				t.Errorf("String() = %v, want %v", w.String(), got)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	order, _ := hex.DecodeString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	payload := func(parts ...[]byte) string {
+		return base58.CheckEncode(bytes.Join(parts, nil))
+	}
+	tests := []struct {
+		name string
+		s    string
+		want error
+	}{
+		{"checksum", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK", base58.ErrInvalidChecksum},
+		{"char", "0HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", base58.ErrInvalidChar},
+		{"short", payload([]byte{0x80}, testKey[:31]), ErrInvalidLength},
+		{"long", payload([]byte{0x80}, testKey, []byte{0x01, 0x01}), ErrInvalidLength},
+		{"flag", payload([]byte{0x80}, testKey, []byte{0x02}), ErrInvalidFlag},
+		{"version", payload([]byte{0x00}, testKey, []byte{0x01}), ErrInvalidVersion},
+		{"zero", payload([]byte{0x80}, make([]byte, 32)), ErrInvalidPrivateKey},
+		{"order", payload([]byte{0x80}, order, []byte{0x01}), ErrInvalidPrivateKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.s); err != tt.want {
+				t.Errorf("Decode() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Encode(order, true, false); err != ErrInvalidPrivateKey {
+		t.Errorf("Encode() error = %v, want %v", err, ErrInvalidPrivateKey)
+	}
+}
+
+func TestFromKey(t *testing.T) {
+	master, err := bip32.NewMasterKeyFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := master.Derive("m/44'/0'/0'/0/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := FromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "L4p2b9VAf8k5aUahF1JCJUzZkgNEAqLfq8DDdQiyAprQAKSbu8hf"; s != want {
+		t.Errorf("FromKey() = %v, want %v", s, want)
+	}
+	w, _ := Decode(s)
+	if !bytes.Equal(w.PublicKey(), key.PublicKey()) {
+		t.Errorf("PublicKey() = %x, want %x", w.PublicKey(), key.PublicKey())
+	}
+
+	testnet, _ := FromKey(key.SetVersion(bip32.TestnetPrivate))
+	if w, _ := Decode(testnet); !w.Testnet {
+		t.Errorf("FromKey() testnet = %v", testnet)
+	}
+	if _, err := FromKey(key.Neuter()); err != ErrInvalidPrivateKey {
+		t.Errorf("FromKey() error = %v, want %v", err, ErrInvalidPrivateKey)
+	}
+}